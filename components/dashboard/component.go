@@ -73,9 +73,11 @@ func provide(c *dig.Container) error {
 			Component.Daemon(),
 			deps.NodeBridge,
 			hub,
+			dashboard.WithAppVersion(Component.App().Info().Version),
 			dashboard.WithBindAddress(ParamsDashboard.BindAddress),
 			dashboard.WithDeveloperMode(ParamsDashboard.DeveloperMode),
 			dashboard.WithDeveloperModeURL(ParamsDashboard.DeveloperModeURL),
+			dashboard.WithTrustedProxies(ParamsDashboard.TrustedProxies),
 			dashboard.WithAuthUsername(ParamsDashboard.Auth.Username),
 			dashboard.WithAuthPasswordHash(ParamsDashboard.Auth.PasswordHash),
 			dashboard.WithAuthPasswordSalt(ParamsDashboard.Auth.PasswordSalt),
@@ -88,6 +90,43 @@ func provide(c *dig.Container) error {
 			dashboard.WithAuthRateLimitMaxBurst(ParamsDashboard.Auth.RateLimit.MaxBurst),
 			dashboard.WithWebsocketWriteTimeout(webSocketWriteTimeout),
 			dashboard.WithDebugLogRequests(ParamsDashboard.DebugRequestLoggerEnabled),
+			dashboard.WithProxyTimeout(ParamsDashboard.Proxy.Timeout),
+			dashboard.WithProxyMaxBodySize(ParamsDashboard.Proxy.MaxBodySize),
+			dashboard.WithProxyRoutes(ParamsDashboard.Proxy.Routes),
+			dashboard.WithProxyRateLimitEnabled(ParamsDashboard.Proxy.RateLimit.Enabled),
+			dashboard.WithProxyRateLimitPeriod(ParamsDashboard.Proxy.RateLimit.Period),
+			dashboard.WithProxyRateLimitMaxRequests(ParamsDashboard.Proxy.RateLimit.MaxRequests),
+			dashboard.WithProxyRateLimitMaxBurst(ParamsDashboard.Proxy.RateLimit.MaxBurst),
+			dashboard.WithProxyRateLimitIndexerMaxRequests(ParamsDashboard.Proxy.RateLimit.IndexerMaxRequests),
+			dashboard.WithProxyRateLimitIndexerMaxBurst(ParamsDashboard.Proxy.RateLimit.IndexerMaxBurst),
+			dashboard.WithProxyRateLimitAuthenticatedMaxRequests(ParamsDashboard.Proxy.RateLimit.AuthenticatedMaxRequests),
+			dashboard.WithProxyRateLimitAuthenticatedMaxBurst(ParamsDashboard.Proxy.RateLimit.AuthenticatedMaxBurst),
+			dashboard.WithProxyRateLimitAuthenticatedIndexerMaxRequests(ParamsDashboard.Proxy.RateLimit.AuthenticatedIndexerMaxRequests),
+			dashboard.WithProxyRateLimitAuthenticatedIndexerMaxBurst(ParamsDashboard.Proxy.RateLimit.AuthenticatedIndexerMaxBurst),
+			dashboard.WithProxyCacheEnabled(ParamsDashboard.Proxy.Cache.Enabled),
+			dashboard.WithProxyCacheMaxEntries(ParamsDashboard.Proxy.Cache.MaxEntries),
+			dashboard.WithProxyCacheMaxSize(ParamsDashboard.Proxy.Cache.MaxSize),
+			dashboard.WithProxyFailoverEndpoints(ParamsDashboard.Proxy.Failover.Endpoints),
+			dashboard.WithProxyFailoverHealthCheckInterval(ParamsDashboard.Proxy.Failover.HealthCheckInterval),
+			dashboard.WithProxyFailoverFailureThreshold(ParamsDashboard.Proxy.Failover.FailureThreshold),
+			dashboard.WithProxyFailoverOpenPeriod(ParamsDashboard.Proxy.Failover.OpenPeriod),
+			dashboard.WithVisualizerCapacity(ParamsDashboard.Visualizer.Capacity),
+			dashboard.WithVisualizerInitValues(ParamsDashboard.Visualizer.InitValues),
+			dashboard.WithVisualizerAlwaysActive(ParamsDashboard.Visualizer.AlwaysActive),
+			dashboard.WithVisualizerLingerPeriod(ParamsDashboard.Visualizer.LingerPeriod),
+			dashboard.WithAlertsEnabled(ParamsDashboard.Alerts.Enabled),
+			dashboard.WithAlertsCheckInterval(ParamsDashboard.Alerts.CheckInterval),
+			dashboard.WithAlertsNodeUnsynced(ParamsDashboard.Alerts.NodeUnsynced),
+			dashboard.WithAlertsMinPeers(ParamsDashboard.Alerts.MinPeers),
+			dashboard.WithAlertsMaxDatabaseGrowthPerHour(ParamsDashboard.Alerts.MaxDatabaseGrowthPerHour),
+			dashboard.WithAlertsMaxMilestoneLag(ParamsDashboard.Alerts.MaxMilestoneLag),
+			dashboard.WithWebhooksURLs(ParamsDashboard.Webhooks.URLs),
+			dashboard.WithWebhooksSecret(ParamsDashboard.Webhooks.Secret),
+			dashboard.WithWebhooksMaxRetries(ParamsDashboard.Webhooks.MaxRetries),
+			dashboard.WithWebhooksRetryBackoff(ParamsDashboard.Webhooks.RetryBackoff),
+			dashboard.WithTracingEnabled(ParamsDashboard.Tracing.Enabled),
+			dashboard.WithTracingCollectorEndpoint(ParamsDashboard.Tracing.CollectorEndpoint),
+			dashboard.WithTracingSampleRatio(ParamsDashboard.Tracing.SampleRatio),
 		)
 	}); err != nil {
 		return err
@@ -18,6 +18,8 @@ type ParametersDashboard struct {
 	DeveloperMode bool `default:"false" usage:"whether to run the dashboard in dev mode"`
 	// DeveloperModeURL defines the URL to use for dev mode
 	DeveloperModeURL string `name:"developerModeURL" default:"http://127.0.0.1:9090" usage:"the URL to use for dev mode"`
+	// TrustedProxies defines the IP ranges of reverse proxies whose X-Forwarded-For header identifies the clients
+	TrustedProxies []string `default:"" usage:"the IP ranges (CIDR) of reverse proxies that are trusted to set the X-Forwarded-For header (required behind a reverse proxy, otherwise all clients share the rate limit of the proxy)"`
 
 	Auth struct {
 		// SessionTimeout defines how long the auth session should last before expiring
@@ -41,6 +43,104 @@ type ParametersDashboard struct {
 		}
 	}
 
+	Proxy struct {
+		// Timeout defines the timeout of requests forwarded to the node
+		Timeout time.Duration `default:"30s" usage:"the timeout of requests forwarded to the node"`
+		// MaxBodySize defines the maximum size in bytes of forwarded request and response bodies
+		MaxBodySize int64 `default:"10485760" usage:"the maximum size in bytes of forwarded request and response bodies"`
+		// Routes defines additional node API routes that are forwarded
+		Routes []string `default:"" usage:"additional node API routes that are forwarded, in the format \"<feature> <method> <path> <auth> [cacheable]\" with auth being \"public\" or \"protected\" (replaces built-in routes with the same method and path)"`
+
+		RateLimit struct {
+			// Enabled defines whether the forwarded node API routes are rate limited per client
+			Enabled bool `default:"true" usage:"whether the forwarded node API routes are rate limited per client"`
+			// Period defines the period for rate limiting
+			Period time.Duration `default:"1m" usage:"the period for rate limiting"`
+			// MaxRequests defines the maximum number of requests per period of a client IP
+			MaxRequests int `default:"300" usage:"the maximum number of requests per period of a client IP"`
+			// MaxBurst defines the additional requests allowed in the burst period of a client IP
+			MaxBurst int `default:"100" usage:"additional requests allowed in the burst period of a client IP"`
+			// IndexerMaxRequests defines the maximum number of indexer queries per period of a client IP
+			IndexerMaxRequests int `default:"60" usage:"the maximum number of indexer queries per period of a client IP"`
+			// IndexerMaxBurst defines the additional indexer queries allowed in the burst period of a client IP
+			IndexerMaxBurst int `default:"20" usage:"additional indexer queries allowed in the burst period of a client IP"`
+			// AuthenticatedMaxRequests defines the maximum number of requests per period of an authenticated subject
+			AuthenticatedMaxRequests int `default:"3000" usage:"the maximum number of requests per period of an authenticated subject"`
+			// AuthenticatedMaxBurst defines the additional requests allowed in the burst period of an authenticated subject
+			AuthenticatedMaxBurst int `default:"1000" usage:"additional requests allowed in the burst period of an authenticated subject"`
+			// AuthenticatedIndexerMaxRequests defines the maximum number of indexer queries per period of an authenticated subject
+			AuthenticatedIndexerMaxRequests int `default:"600" usage:"the maximum number of indexer queries per period of an authenticated subject"`
+			// AuthenticatedIndexerMaxBurst defines the additional indexer queries allowed in the burst period of an authenticated subject
+			AuthenticatedIndexerMaxBurst int `default:"200" usage:"additional indexer queries allowed in the burst period of an authenticated subject"`
+		}
+
+		Cache struct {
+			// Enabled defines whether immutable node API responses (blocks, milestones, included blocks) are cached
+			Enabled bool `default:"true" usage:"whether immutable node API responses (blocks, milestones, included blocks) are cached"`
+			// MaxEntries defines the maximum amount of cached responses
+			MaxEntries int `default:"10000" usage:"the maximum amount of cached responses"`
+			// MaxSize defines the maximum size in bytes of all cached responses
+			MaxSize int64 `default:"67108864" usage:"the maximum size in bytes of all cached responses"`
+		}
+
+		Failover struct {
+			// Endpoints defines additional node REST API endpoints used for read-only requests of public routes if the INX connected node is unhealthy or times out
+			Endpoints []string `default:"" usage:"additional node REST API endpoints used for read-only requests of public routes if the INX connected node is unhealthy or times out"`
+			// HealthCheckInterval defines the interval in which the health of the nodes is checked
+			HealthCheckInterval time.Duration `default:"10s" usage:"the interval in which the health of the nodes is checked"`
+			// FailureThreshold defines the amount of consecutive failed requests after which a node is no longer used
+			FailureThreshold int `default:"3" usage:"the amount of consecutive failed requests after which a node is no longer used"`
+			// OpenPeriod defines how long a failed node is not used before it is tried again
+			OpenPeriod time.Duration `default:"30s" usage:"how long a failed node is not used before it is tried again"`
+		}
+	}
+
+	Visualizer struct {
+		// Capacity defines the maximum amount of vertices held by the visualizer
+		Capacity int `default:"3000" usage:"the maximum amount of vertices held by the visualizer"`
+		// InitValues defines the amount of vertices sent to newly subscribed clients
+		InitValues int `default:"3000" usage:"the amount of vertices sent to newly subscribed clients"`
+		// AlwaysActive defines whether the visualizer keeps tracking blocks without any subscribers
+		AlwaysActive bool `default:"false" usage:"whether the visualizer keeps tracking blocks without any subscribers (needed for the tangle analytics metrics)"`
+		// LingerPeriod defines how long the visualizer keeps tracking blocks after the last subscriber left
+		LingerPeriod time.Duration `default:"1m" usage:"how long the visualizer keeps tracking blocks after the last subscriber left, so that returning viewers get the full window"`
+	}
+
+	Alerts struct {
+		// Enabled defines whether the alerting engine is enabled
+		Enabled bool `default:"false" usage:"whether the alerting engine is enabled"`
+		// CheckInterval defines the interval in which the alert rules are evaluated
+		CheckInterval time.Duration `default:"10s" usage:"the interval in which the alert rules are evaluated"`
+		// NodeUnsynced defines whether to alert if the node is not synced
+		NodeUnsynced bool `default:"true" usage:"whether to alert if the node is not synced"`
+		// MinPeers defines the minimum amount of connected peers before an alert is fired
+		MinPeers int `default:"1" usage:"the minimum amount of connected peers before an alert is fired (0 to disable)"`
+		// MaxDatabaseGrowthPerHour defines the maximum database growth in bytes per hour before an alert is fired
+		MaxDatabaseGrowthPerHour int64 `default:"0" usage:"the maximum database growth in bytes per hour before an alert is fired (0 to disable)"`
+		// MaxMilestoneLag defines the maximum amount of milestones the CMI may lag behind the LMI before an alert is fired
+		MaxMilestoneLag uint32 `default:"5" usage:"the maximum amount of milestones the CMI may lag behind the LMI before an alert is fired (0 to disable)"`
+	}
+
+	Webhooks struct {
+		// URLs defines the URLs that are notified about node state transitions and alerts
+		URLs []string `name:"urls" default:"" usage:"the URLs that are notified about node state transitions and alerts"`
+		// Secret defines the secret used to sign the webhook payloads
+		Secret string `default:"" usage:"the secret used to sign the webhook payloads with HMAC-SHA256 (optional)"`
+		// MaxRetries defines how often a failed delivery is retried
+		MaxRetries int `default:"3" usage:"how often a failed delivery is retried"`
+		// RetryBackoff defines the initial backoff between retries
+		RetryBackoff time.Duration `default:"1s" usage:"the initial backoff between retries, doubled after every retry"`
+	}
+
+	Tracing struct {
+		// Enabled defines whether the proxied requests are exported as OpenTelemetry spans
+		Enabled bool `default:"false" usage:"whether the proxied requests are exported as OpenTelemetry spans"`
+		// CollectorEndpoint defines the host:port of the OTLP/HTTP collector the spans are exported to
+		CollectorEndpoint string `default:"localhost:4318" usage:"the host:port of the OTLP/HTTP collector the spans are exported to"`
+		// SampleRatio defines the ratio of the traces that are exported
+		SampleRatio float64 `default:"1.0" usage:"the ratio of the traces that are exported, unless an authorized client decided already"`
+	}
+
 	// whether the debug logging for requests should be enabled
 	DebugRequestLoggerEnabled bool `default:"false" usage:"whether the debug logging for requests should be enabled"`
 }
@@ -51,5 +151,5 @@ var params = &app.ComponentParams{
 	Params: map[string]any{
 		"dashboard": ParamsDashboard,
 	},
-	Masked: []string{"dashboard.auth.passwordHash", "dashboard.auth.passwordSalt"},
+	Masked: []string{"dashboard.auth.passwordHash", "dashboard.auth.passwordSalt", "dashboard.webhooks.secret"},
 }
@@ -15,6 +15,7 @@ import (
 
 	"github.com/iotaledger/hive.go/app"
 	"github.com/iotaledger/inx-dashboard/pkg/daemon"
+	"github.com/iotaledger/inx-dashboard/pkg/dashboard"
 )
 
 func init() {
@@ -32,6 +33,7 @@ func init() {
 type dependencies struct {
 	dig.In
 	PrometheusEcho *echo.Echo `name:"prometheusEcho"`
+	Dashboard      *dashboard.Dashboard
 }
 
 var (
@@ -119,5 +121,9 @@ func registerMetrics() *prometheus.Registry {
 		registry.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
 	}
 
+	configureTangle(registry)
+	configureResponseCache(registry)
+	configureRateLimit(registry)
+
 	return registry
 }
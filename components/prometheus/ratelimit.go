@@ -0,0 +1,58 @@
+package prometheus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// rateLimitCollector exports the state of the rate limit budgets of the proxied node API routes.
+type rateLimitCollector struct {
+	allowed    *prometheus.Desc
+	rejected   *prometheus.Desc
+	overflowed *prometheus.Desc
+	clients    *prometheus.Desc
+}
+
+func newRateLimitCollector() *rateLimitCollector {
+	return &rateLimitCollector{
+		allowed: prometheus.NewDesc(
+			prometheus.BuildFQName("iota", "dashboard_rate_limit", "allowed_requests_total"),
+			"Number of proxied requests allowed by the rate limiter.",
+			[]string{"budget"}, nil,
+		),
+		rejected: prometheus.NewDesc(
+			prometheus.BuildFQName("iota", "dashboard_rate_limit", "rejected_requests_total"),
+			"Number of proxied requests rejected by the rate limiter.",
+			[]string{"budget"}, nil,
+		),
+		overflowed: prometheus.NewDesc(
+			prometheus.BuildFQName("iota", "dashboard_rate_limit", "overflowed_requests_total"),
+			"Number of proxied requests of clients sharing a budget because the maximum amount of tracked clients was reached.",
+			[]string{"budget"}, nil,
+		),
+		clients: prometheus.NewDesc(
+			prometheus.BuildFQName("iota", "dashboard_rate_limit", "tracked_clients"),
+			"Number of clients currently tracked by the rate limiter.",
+			[]string{"budget"}, nil,
+		),
+	}
+}
+
+func (c *rateLimitCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.allowed
+	ch <- c.rejected
+	ch <- c.overflowed
+	ch <- c.clients
+}
+
+func (c *rateLimitCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, metrics := range deps.Dashboard.RateLimitMetrics() {
+		ch <- prometheus.MustNewConstMetric(c.allowed, prometheus.CounterValue, float64(metrics.Allowed), metrics.Name)
+		ch <- prometheus.MustNewConstMetric(c.rejected, prometheus.CounterValue, float64(metrics.Rejected), metrics.Name)
+		ch <- prometheus.MustNewConstMetric(c.overflowed, prometheus.CounterValue, float64(metrics.Overflowed), metrics.Name)
+		ch <- prometheus.MustNewConstMetric(c.clients, prometheus.GaugeValue, float64(metrics.Clients), metrics.Name)
+	}
+}
+
+func configureRateLimit(registry *prometheus.Registry) {
+	registry.MustRegister(newRateLimitCollector())
+}
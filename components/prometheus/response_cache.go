@@ -0,0 +1,63 @@
+package prometheus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotaledger/inx-dashboard/pkg/cache"
+)
+
+var (
+	responseCacheHits      prometheus.CounterFunc
+	responseCacheMisses    prometheus.CounterFunc
+	responseCacheEvictions prometheus.CounterFunc
+	responseCacheEntries   prometheus.GaugeFunc
+	responseCacheSize      prometheus.GaugeFunc
+)
+
+// responseCacheMetric returns the value of a response cache metric, or zero if the cache is disabled.
+func responseCacheMetric(value func(metrics *cache.Metrics) float64) func() float64 {
+	return func() float64 {
+		metrics := deps.Dashboard.ResponseCacheMetrics()
+		if metrics == nil {
+			return 0
+		}
+
+		return value(metrics)
+	}
+}
+
+func configureResponseCache(registry *prometheus.Registry) {
+	counterOpts := func(name string, help string) prometheus.CounterOpts {
+		return prometheus.CounterOpts{
+			Namespace: "iota",
+			Subsystem: "dashboard_response_cache",
+			Name:      name,
+			Help:      help,
+		}
+	}
+	gaugeOpts := func(name string, help string) prometheus.GaugeOpts {
+		return prometheus.GaugeOpts{
+			Namespace: "iota",
+			Subsystem: "dashboard_response_cache",
+			Name:      name,
+			Help:      help,
+		}
+	}
+
+	responseCacheHits = prometheus.NewCounterFunc(counterOpts("hits_total", "Number of node API responses served from the cache."),
+		responseCacheMetric(func(metrics *cache.Metrics) float64 { return float64(metrics.Hits) }))
+	responseCacheMisses = prometheus.NewCounterFunc(counterOpts("misses_total", "Number of cacheable node API requests forwarded to the node."),
+		responseCacheMetric(func(metrics *cache.Metrics) float64 { return float64(metrics.Misses) }))
+	responseCacheEvictions = prometheus.NewCounterFunc(counterOpts("evictions_total", "Number of responses evicted from the cache."),
+		responseCacheMetric(func(metrics *cache.Metrics) float64 { return float64(metrics.Evictions) }))
+	responseCacheEntries = prometheus.NewGaugeFunc(gaugeOpts("entries", "Number of cached responses."),
+		responseCacheMetric(func(metrics *cache.Metrics) float64 { return float64(metrics.Entries) }))
+	responseCacheSize = prometheus.NewGaugeFunc(gaugeOpts("size_bytes", "Size of all cached responses."),
+		responseCacheMetric(func(metrics *cache.Metrics) float64 { return float64(metrics.Size) }))
+
+	registry.MustRegister(responseCacheHits)
+	registry.MustRegister(responseCacheMisses)
+	registry.MustRegister(responseCacheEvictions)
+	registry.MustRegister(responseCacheEntries)
+	registry.MustRegister(responseCacheSize)
+}
@@ -0,0 +1,116 @@
+package prometheus
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotaledger/inx-dashboard/pkg/dashboard"
+)
+
+var (
+	tangleTimeToSolidify  prometheus.Histogram
+	tangleTimeToReference prometheus.Histogram
+)
+
+// tangleMetric is a gauge derived from the tangle analytics.
+type tangleMetric struct {
+	desc  *prometheus.Desc
+	value func(analytics *dashboard.TangleAnalytics) float64
+}
+
+// tangleCollector computes the tangle analytics once per scrape, because it has to scan the whole visualizer window.
+type tangleCollector struct {
+	metrics []*tangleMetric
+}
+
+func newTangleMetric(name string, help string, value func(analytics *dashboard.TangleAnalytics) float64) *tangleMetric {
+	return &tangleMetric{
+		desc:  prometheus.NewDesc(prometheus.BuildFQName("iota", "dashboard_tangle", name), help, nil, nil),
+		value: value,
+	}
+}
+
+func newTangleCollector() *tangleCollector {
+	return &tangleCollector{
+		metrics: []*tangleMetric{
+			newTangleMetric("blocks", "Number of blocks in the visualizer window.", func(analytics *dashboard.TangleAnalytics) float64 {
+				return float64(analytics.Blocks)
+			}),
+			newTangleMetric("tip_pool_size", "Number of blocks not referenced by other blocks yet.", func(analytics *dashboard.TangleAnalytics) float64 {
+				return float64(analytics.TipPoolSize)
+			}),
+			newTangleMetric("average_parents", "Average number of parents per block.", func(analytics *dashboard.TangleAnalytics) float64 {
+				return analytics.AverageParents
+			}),
+			newTangleMetric("average_time_to_solidify_seconds", "Average time between the arrival and the solidification of a block.", func(analytics *dashboard.TangleAnalytics) float64 {
+				return analytics.AverageTimeToSolidify / 1000
+			}),
+			newTangleMetric("average_time_to_reference_seconds", "Average time between the arrival of a block and its reference by a milestone.", func(analytics *dashboard.TangleAnalytics) float64 {
+				return analytics.AverageTimeToReference / 1000
+			}),
+			newTangleMetric("transaction_share", "Share of blocks with a transaction payload.", func(analytics *dashboard.TangleAnalytics) float64 {
+				return analytics.TransactionShare
+			}),
+			newTangleMetric("milestone_share", "Share of blocks with a milestone payload.", func(analytics *dashboard.TangleAnalytics) float64 {
+				return analytics.MilestoneShare
+			}),
+			newTangleMetric("tagged_data_share", "Share of blocks with a tagged data payload.", func(analytics *dashboard.TangleAnalytics) float64 {
+				return analytics.TaggedDataShare
+			}),
+			newTangleMetric("orphan_rate", "Share of blocks older than the orphan threshold that were not referenced by a milestone.", func(analytics *dashboard.TangleAnalytics) float64 {
+				return analytics.OrphanRate
+			}),
+		},
+	}
+}
+
+func (c *tangleCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, metric := range c.metrics {
+		ch <- metric.desc
+	}
+}
+
+func (c *tangleCollector) Collect(ch chan<- prometheus.Metric) {
+	if !deps.Dashboard.Visualizer().IsActive() {
+		// the window is not updated while the visualizer is inactive, the values would be stale
+		return
+	}
+
+	analytics := deps.Dashboard.TangleAnalytics()
+	for _, metric := range c.metrics {
+		ch <- prometheus.MustNewConstMetric(metric.desc, prometheus.GaugeValue, metric.value(analytics))
+	}
+}
+
+func configureTangle(registry *prometheus.Registry) {
+	tangleTimeToSolidify = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "iota",
+			Subsystem: "dashboard_tangle",
+			Name:      "time_to_solidify_seconds",
+			Help:      "Time between the arrival and the solidification of a block.",
+			Buckets:   []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		},
+	)
+	tangleTimeToReference = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "iota",
+			Subsystem: "dashboard_tangle",
+			Name:      "time_to_reference_seconds",
+			Help:      "Time between the arrival of a block and its reference by a milestone.",
+			Buckets:   []float64{1, 2.5, 5, 7.5, 10, 15, 20, 30, 60, 120},
+		},
+	)
+
+	deps.Dashboard.Visualizer().Events.BlockSolidified.Hook(func(latency time.Duration) {
+		tangleTimeToSolidify.Observe(latency.Seconds())
+	})
+	deps.Dashboard.Visualizer().Events.BlockReferenced.Hook(func(latency time.Duration) {
+		tangleTimeToReference.Observe(latency.Seconds())
+	})
+
+	registry.MustRegister(newTangleCollector())
+	registry.MustRegister(tangleTimeToSolidify)
+	registry.MustRegister(tangleTimeToReference)
+}
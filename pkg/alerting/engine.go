@@ -0,0 +1,154 @@
+package alerting
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/runtime/event"
+)
+
+// State is the state of an alert.
+type State string
+
+const (
+	// StateFiring signals that the condition of a rule is met.
+	StateFiring State = "firing"
+	// StateResolved signals that the condition of a previously firing rule is no longer met.
+	StateResolved State = "resolved"
+)
+
+// Condition evaluates a rule.
+// It returns whether the rule fires and a message describing the current value.
+// If an error is returned, the state of the alert is left unchanged.
+type Condition func() (firing bool, message string, err error)
+
+// Alert is the state of a rule that fired at least once.
+type Alert struct {
+	// Rule is the name of the rule.
+	Rule string `json:"rule"`
+	// State is the current state of the alert.
+	State State `json:"state"`
+	// Message describes the value that caused the state change.
+	Message string `json:"message"`
+	// Since is the unix timestamp of the last state change.
+	Since int64 `json:"since"`
+}
+
+type rule struct {
+	name      string
+	condition Condition
+}
+
+// Engine evaluates rules and tracks the firing/resolved state of their alerts.
+type Engine struct {
+	sync.RWMutex
+
+	rules  []*rule
+	alerts map[string]*Alert
+
+	Events *Events
+}
+
+// Events are the events issued by the Engine.
+type Events struct {
+	// AlertChanged is triggered if an alert starts firing or is resolved.
+	AlertChanged *event.Event1[*Alert]
+}
+
+// NewEngine creates a new alerting engine.
+func NewEngine() *Engine {
+	return &Engine{
+		alerts: make(map[string]*Alert),
+		Events: &Events{
+			AlertChanged: event.New1[*Alert](),
+		},
+	}
+}
+
+// AddRule adds a rule with the given name to the engine.
+func (e *Engine) AddRule(name string, condition Condition) {
+	e.Lock()
+	defer e.Unlock()
+
+	e.rules = append(e.rules, &rule{
+		name:      name,
+		condition: condition,
+	})
+}
+
+// Evaluate evaluates all rules and triggers the AlertChanged event for every state transition.
+func (e *Engine) Evaluate() {
+	e.RLock()
+	rules := make([]*rule, len(e.rules))
+	copy(rules, e.rules)
+	e.RUnlock()
+
+	for _, r := range rules {
+		firing, message, err := r.condition()
+		if err != nil {
+			continue
+		}
+
+		if alert := e.updateAlert(r.name, firing, message); alert != nil {
+			e.Events.AlertChanged.Trigger(alert)
+		}
+	}
+}
+
+// updateAlert updates the state of the alert of the given rule.
+// It returns a copy of the alert if the state changed, nil otherwise.
+func (e *Engine) updateAlert(name string, firing bool, message string) *Alert {
+	e.Lock()
+	defer e.Unlock()
+
+	alert, exists := e.alerts[name]
+	if !exists {
+		if !firing {
+			// rule never fired, nothing to resolve
+			return nil
+		}
+
+		alert = &Alert{Rule: name}
+		e.alerts[name] = alert
+	}
+
+	state := StateResolved
+	if firing {
+		state = StateFiring
+	}
+
+	if alert.State == state {
+		return nil
+	}
+
+	alert.State = state
+	alert.Message = message
+	alert.Since = time.Now().Unix()
+
+	alertCopy := *alert
+
+	return &alertCopy
+}
+
+// Alerts returns all currently firing alerts.
+func (e *Engine) Alerts() []*Alert {
+	e.RLock()
+	defer e.RUnlock()
+
+	alerts := make([]*Alert, 0)
+	for _, alert := range e.alerts {
+		if alert.State != StateFiring {
+			continue
+		}
+
+		alertCopy := *alert
+		alerts = append(alerts, &alertCopy)
+	}
+
+	sort.Slice(alerts, func(i, j int) bool {
+		return alerts[i].Rule < alerts[j].Rule
+	})
+
+	return alerts
+}
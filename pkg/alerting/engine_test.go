@@ -0,0 +1,122 @@
+package alerting
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// evaluation is the result of a condition in a single evaluation.
+type evaluation struct {
+	firing bool
+	err    error
+}
+
+func TestEngineEvaluate(t *testing.T) {
+	errCondition := errors.New("value not available")
+
+	tests := []struct {
+		name        string
+		evaluations []evaluation
+		// wantStates are the states of the triggered AlertChanged events.
+		wantStates []State
+		wantFiring bool
+	}{
+		{
+			name:        "never firing",
+			evaluations: []evaluation{{firing: false}, {firing: false}},
+		},
+		{
+			name:        "firing",
+			evaluations: []evaluation{{firing: true}},
+			wantStates:  []State{StateFiring},
+			wantFiring:  true,
+		},
+		{
+			name:        "firing is only reported once",
+			evaluations: []evaluation{{firing: true}, {firing: true}, {firing: true}},
+			wantStates:  []State{StateFiring},
+			wantFiring:  true,
+		},
+		{
+			name:        "resolved",
+			evaluations: []evaluation{{firing: true}, {firing: false}, {firing: false}},
+			wantStates:  []State{StateFiring, StateResolved},
+		},
+		{
+			name:        "firing again",
+			evaluations: []evaluation{{firing: true}, {firing: false}, {firing: true}},
+			wantStates:  []State{StateFiring, StateResolved, StateFiring},
+			wantFiring:  true,
+		},
+		{
+			name:        "errors keep the state",
+			evaluations: []evaluation{{firing: true}, {err: errCondition}, {firing: true}},
+			wantStates:  []State{StateFiring},
+			wantFiring:  true,
+		},
+		{
+			name:        "errors don't resolve",
+			evaluations: []evaluation{{firing: true}, {firing: false, err: errCondition}},
+			wantStates:  []State{StateFiring},
+			wantFiring:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			engine := NewEngine()
+
+			var current evaluation
+			engine.AddRule("rule", func() (bool, string, error) {
+				return current.firing, "message", current.err
+			})
+
+			var states []State
+			engine.Events.AlertChanged.Hook(func(alert *Alert) {
+				if alert.Rule != "rule" || alert.Message != "message" {
+					t.Errorf("unexpected alert %+v", alert)
+				}
+				states = append(states, alert.State)
+			})
+
+			for _, current = range test.evaluations {
+				engine.Evaluate()
+			}
+
+			if len(states) != len(test.wantStates) {
+				t.Fatalf("got states %v, want %v", states, test.wantStates)
+			}
+			for i := range states {
+				if states[i] != test.wantStates[i] {
+					t.Fatalf("got states %v, want %v", states, test.wantStates)
+				}
+			}
+
+			if firing := len(engine.Alerts()) == 1; firing != test.wantFiring {
+				t.Fatalf("got firing %t, want %t", firing, test.wantFiring)
+			}
+		})
+	}
+}
+
+func TestEngineAlertsSorted(t *testing.T) {
+	engine := NewEngine()
+	for _, name := range []string{"c", "a", "resolved", "b"} {
+		firing := name != "resolved"
+		engine.AddRule(name, func() (bool, string, error) {
+			return firing, "", nil
+		})
+	}
+	engine.Evaluate()
+
+	alerts := engine.Alerts()
+	if len(alerts) != 3 {
+		t.Fatalf("got %d firing alerts, want 3", len(alerts))
+	}
+	for i, name := range []string{"a", "b", "c"} {
+		if alerts[i].Rule != name {
+			t.Fatalf("got alert %s at position %d, want %s", alerts[i].Rule, i, name)
+		}
+	}
+}
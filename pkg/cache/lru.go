@@ -0,0 +1,116 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Entry is a value stored in the cache together with its size in bytes.
+type Entry struct {
+	Value any
+	Size  int64
+}
+
+type item struct {
+	key   string
+	entry *Entry
+}
+
+// Metrics holds the statistics of a cache.
+type Metrics struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Entries   int
+	Size      int64
+}
+
+// LRU is a least recently used cache that is bounded by the amount of entries and by their total size in bytes.
+// It is safe for concurrent use.
+type LRU struct {
+	sync.Mutex
+
+	maxEntries int
+	maxSize    int64
+
+	items *list.List
+	index map[string]*list.Element
+	size  int64
+
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+// NewLRU creates a new cache. Limits smaller than one disable the respective bound.
+func NewLRU(maxEntries int, maxSize int64) *LRU {
+	return &LRU{
+		maxEntries: maxEntries,
+		maxSize:    maxSize,
+		items:      list.New(),
+		index:      make(map[string]*list.Element),
+	}
+}
+
+// Get returns the entry with the given key and marks it as recently used.
+func (c *LRU) Get(key string) (*Entry, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	element, exists := c.index[key]
+	if !exists {
+		c.misses++
+
+		return nil, false
+	}
+	c.hits++
+
+	c.items.MoveToFront(element)
+
+	//nolint:forcetypeassert // we only store items in the list
+	return element.Value.(*item).entry, true
+}
+
+// Put adds or replaces an entry and evicts the least recently used entries if a bound is exceeded.
+// Entries larger than the maximum size are not stored.
+func (c *LRU) Put(key string, entry *Entry) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.maxSize > 0 && entry.Size > c.maxSize {
+		return
+	}
+
+	if element, exists := c.index[key]; exists {
+		c.removeElement(element)
+	}
+
+	c.index[key] = c.items.PushFront(&item{key: key, entry: entry})
+	c.size += entry.Size
+
+	for (c.maxEntries > 0 && c.items.Len() > c.maxEntries) || (c.maxSize > 0 && c.size > c.maxSize) {
+		c.removeElement(c.items.Back())
+		c.evictions++
+	}
+}
+
+func (c *LRU) removeElement(element *list.Element) {
+	//nolint:forcetypeassert // we only store items in the list
+	removed := c.items.Remove(element).(*item)
+	delete(c.index, removed.key)
+	c.size -= removed.entry.Size
+}
+
+// Metrics returns the current statistics of the cache.
+func (c *LRU) Metrics() *Metrics {
+	c.Lock()
+	defer c.Unlock()
+
+	return &Metrics{
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+		Entries:   c.items.Len(),
+		Size:      c.size,
+	}
+}
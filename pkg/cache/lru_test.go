@@ -0,0 +1,148 @@
+package cache
+
+import (
+	"testing"
+)
+
+func TestLRU(t *testing.T) {
+	type op struct {
+		key  string
+		size int64
+		// get reads the key instead of putting it
+		get bool
+	}
+
+	tests := []struct {
+		name          string
+		maxEntries    int
+		maxSize       int64
+		ops           []op
+		wantKeys      []string
+		wantMissing   []string
+		wantEvictions uint64
+		wantSize      int64
+	}{
+		{
+			name:       "within the bounds",
+			maxEntries: 3,
+			maxSize:    100,
+			ops:        []op{{key: "a", size: 10}, {key: "b", size: 20}},
+			wantKeys:   []string{"a", "b"},
+			wantSize:   30,
+		},
+		{
+			name:          "evicted by entries",
+			maxEntries:    2,
+			ops:           []op{{key: "a", size: 1}, {key: "b", size: 1}, {key: "c", size: 1}},
+			wantKeys:      []string{"b", "c"},
+			wantMissing:   []string{"a"},
+			wantEvictions: 1,
+			wantSize:      2,
+		},
+		{
+			name:          "evicted by size",
+			maxSize:       50,
+			ops:           []op{{key: "a", size: 20}, {key: "b", size: 20}, {key: "c", size: 30}},
+			wantKeys:      []string{"b", "c"},
+			wantMissing:   []string{"a"},
+			wantEvictions: 1,
+			wantSize:      50,
+		},
+		{
+			name:          "recently used entries are kept",
+			maxEntries:    2,
+			ops:           []op{{key: "a", size: 1}, {key: "b", size: 1}, {key: "a", get: true}, {key: "c", size: 1}},
+			wantKeys:      []string{"a", "c"},
+			wantMissing:   []string{"b"},
+			wantEvictions: 1,
+			wantSize:      2,
+		},
+		{
+			name:       "replaced entries",
+			maxEntries: 2,
+			maxSize:    100,
+			ops:        []op{{key: "a", size: 10}, {key: "a", size: 30}},
+			wantKeys:   []string{"a"},
+			wantSize:   30,
+		},
+		{
+			name:        "entries larger than the maximum size",
+			maxSize:     10,
+			ops:         []op{{key: "a", size: 5}, {key: "b", size: 11}},
+			wantKeys:    []string{"a"},
+			wantMissing: []string{"b"},
+			wantSize:    5,
+		},
+		{
+			name:     "unbounded",
+			ops:      []op{{key: "a", size: 1000}, {key: "b", size: 1000}, {key: "c", size: 1000}},
+			wantKeys: []string{"a", "b", "c"},
+			wantSize: 3000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lru := NewLRU(tt.maxEntries, tt.maxSize)
+
+			for _, o := range tt.ops {
+				if o.get {
+					lru.Get(o.key)
+
+					continue
+				}
+				lru.Put(o.key, &Entry{Value: o.key, Size: o.size})
+			}
+
+			// check the metrics before the lookups below change the hits and misses
+			metrics := lru.Metrics()
+			if metrics.Evictions != tt.wantEvictions {
+				t.Errorf("expected %d evictions, got %d", tt.wantEvictions, metrics.Evictions)
+			}
+			if metrics.Size != tt.wantSize {
+				t.Errorf("expected size %d, got %d", tt.wantSize, metrics.Size)
+			}
+			if metrics.Entries != len(tt.wantKeys) {
+				t.Errorf("expected %d entries, got %d", len(tt.wantKeys), metrics.Entries)
+			}
+
+			for _, key := range tt.wantKeys {
+				entry, exists := lru.Get(key)
+				if !exists {
+					t.Errorf("expected entry %s to be cached", key)
+
+					continue
+				}
+				if entry.Value != key {
+					t.Errorf("expected value %s, got %v", key, entry.Value)
+				}
+			}
+			for _, key := range tt.wantMissing {
+				if _, exists := lru.Get(key); exists {
+					t.Errorf("expected entry %s to be evicted", key)
+				}
+			}
+		})
+	}
+}
+
+func TestLRUMetrics(t *testing.T) {
+	lru := NewLRU(1, 0)
+
+	lru.Put("a", &Entry{Value: 1, Size: 1})
+	lru.Get("a")
+	lru.Get("b")
+	lru.Put("b", &Entry{Value: 2, Size: 1})
+	lru.Get("a")
+
+	metrics := lru.Metrics()
+	if metrics.Hits != 1 {
+		t.Errorf("expected 1 hit, got %d", metrics.Hits)
+	}
+	if metrics.Misses != 2 {
+		t.Errorf("expected 2 misses, got %d", metrics.Misses)
+	}
+	if metrics.Evictions != 1 {
+		t.Errorf("expected 1 eviction, got %d", metrics.Evictions)
+	}
+}
@@ -9,4 +9,6 @@ import (
 var (
 	// ErrInvalidParameter defines the invalid parameter error.
 	ErrInvalidParameter = echo.NewHTTPError(http.StatusBadRequest, "invalid parameter")
+	// ErrNotFound defines the not found error.
+	ErrNotFound = echo.NewHTTPError(http.StatusNotFound, "not found")
 )
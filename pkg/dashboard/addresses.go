@@ -0,0 +1,243 @@
+package dashboard
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"sort"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/inx-dashboard/pkg/common"
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/iota.go/v3/nodeclient"
+)
+
+const (
+	// ParameterBech32Address is used to identify an address by its bech32 representation.
+	ParameterBech32Address = "bech32"
+
+	// AddressMaxOutputs is the maximum amount of outputs that are resolved for a single address.
+	AddressMaxOutputs = 1000
+	// addressOutputsPageSize is the amount of outputs queried from the indexer at once.
+	// The outputs of a page are looked up within the deadline of the page.
+	addressOutputsPageSize = 100
+)
+
+// AddressNativeTokenBalance is the summed up amount of a native token owned by an address.
+type AddressNativeTokenBalance struct {
+	ID string `json:"id"`
+	// Amount is the hex encoded uint256 amount.
+	Amount string `json:"amount"`
+}
+
+// StorageDepositReturnObligation is an amount of base tokens that has to be returned
+// to the sender when the output owned by the address is consumed.
+type StorageDepositReturnObligation struct {
+	OutputID      string `json:"outputId"`
+	ReturnAddress string `json:"returnAddress"`
+	Amount        uint64 `json:"amount,string"`
+	// ExpirationUnixTime is the time after which the output (and therefore the obligation) falls back to the expiration return address.
+	ExpirationUnixTime uint32 `json:"expirationUnixTime,omitempty"`
+}
+
+// AddressBalance is the aggregated view of all unspent outputs owned by an address.
+type AddressBalance struct {
+	Address     string                `json:"address"`
+	LedgerIndex iotago.MilestoneIndex `json:"ledgerIndex"`
+	// BaseTokenBalance is the sum of the base tokens of all owned outputs, including the storage deposits that have to be returned.
+	BaseTokenBalance uint64                       `json:"baseTokenBalance,string"`
+	NativeTokens     []*AddressNativeTokenBalance `json:"nativeTokens"`
+	// StorageDepositReturnAmount is the sum of all storage deposit return obligations.
+	StorageDepositReturnAmount uint64                            `json:"storageDepositReturnAmount,string"`
+	StorageDepositReturns      []*StorageDepositReturnObligation `json:"storageDepositReturns"`
+	BasicOutputs               []string                          `json:"basicOutputs"`
+	AliasOutputs               []string                          `json:"aliasOutputs"`
+	NFTOutputs                 []string                          `json:"nftOutputs"`
+	// Truncated is set if the address owns more than AddressMaxOutputs outputs, in that case the balances are incomplete.
+	Truncated bool `json:"truncated"`
+}
+
+// addressBalanceAggregator sums up the outputs of an address.
+type addressBalanceAggregator struct {
+	balance      *AddressBalance
+	prefix       iotago.NetworkPrefix
+	nativeTokens iotago.NativeTokenSum
+	outputs      int
+}
+
+func newAddressBalanceAggregator(bech32Address string, prefix iotago.NetworkPrefix) *addressBalanceAggregator {
+	return &addressBalanceAggregator{
+		balance: &AddressBalance{
+			Address:               bech32Address,
+			NativeTokens:          make([]*AddressNativeTokenBalance, 0),
+			StorageDepositReturns: make([]*StorageDepositReturnObligation, 0),
+			BasicOutputs:          make([]string, 0),
+			AliasOutputs:          make([]string, 0),
+			NFTOutputs:            make([]string, 0),
+		},
+		prefix:       prefix,
+		nativeTokens: make(iotago.NativeTokenSum),
+	}
+}
+
+// add adds the output to the balance and returns false if the maximum amount of outputs was reached.
+func (a *addressBalanceAggregator) add(outputID iotago.OutputID, output iotago.Output) bool {
+	if a.outputs >= AddressMaxOutputs {
+		a.balance.Truncated = true
+
+		return false
+	}
+	a.outputs++
+
+	outputIDHex := outputID.ToHex()
+	switch output.(type) {
+	case *iotago.BasicOutput:
+		a.balance.BasicOutputs = append(a.balance.BasicOutputs, outputIDHex)
+	case *iotago.AliasOutput:
+		a.balance.AliasOutputs = append(a.balance.AliasOutputs, outputIDHex)
+	case *iotago.NFTOutput:
+		a.balance.NFTOutputs = append(a.balance.NFTOutputs, outputIDHex)
+	}
+
+	a.balance.BaseTokenBalance += output.Deposit()
+
+	for _, nativeToken := range output.NativeTokenList() {
+		sum, exists := a.nativeTokens[nativeToken.ID]
+		if !exists {
+			sum = new(big.Int)
+			a.nativeTokens[nativeToken.ID] = sum
+		}
+		sum.Add(sum, nativeToken.Amount)
+	}
+
+	unlockConditions := output.UnlockConditionSet()
+	if storageDepositReturn := unlockConditions.StorageDepositReturn(); storageDepositReturn != nil {
+		obligation := &StorageDepositReturnObligation{
+			OutputID:      outputIDHex,
+			ReturnAddress: storageDepositReturn.ReturnAddress.Bech32(a.prefix),
+			Amount:        storageDepositReturn.Amount,
+		}
+		if expiration := unlockConditions.Expiration(); expiration != nil {
+			obligation.ExpirationUnixTime = expiration.UnixTime
+		}
+
+		a.balance.StorageDepositReturnAmount += storageDepositReturn.Amount
+		a.balance.StorageDepositReturns = append(a.balance.StorageDepositReturns, obligation)
+	}
+
+	return true
+}
+
+// result returns the aggregated balance with the native tokens sorted by their ID.
+func (a *addressBalanceAggregator) result() *AddressBalance {
+	for id, amount := range a.nativeTokens {
+		a.balance.NativeTokens = append(a.balance.NativeTokens, &AddressNativeTokenBalance{
+			ID:     id.ToHex(),
+			Amount: iotago.EncodeUint256(amount),
+		})
+	}
+
+	sort.Slice(a.balance.NativeTokens, func(i, j int) bool {
+		return a.balance.NativeTokens[i].ID < a.balance.NativeTokens[j].ID
+	})
+
+	return a.balance
+}
+
+// addressBalance pages through the indexer for all basic, alias and NFT outputs owned by the address
+// and sums up their balances. Alias outputs are owned by their state controller.
+func (d *Dashboard) addressBalance(ctx context.Context, bech32Address string) (*AddressBalance, error) {
+	prefix, _, err := iotago.ParseBech32(bech32Address)
+	if err != nil {
+		return nil, errors.WithMessagef(common.ErrInvalidParameter, "invalid bech32 address: %s, error: %s", bech32Address, err)
+	}
+
+	if !d.nodeFeatures.Available(FeatureIndexer) {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "feature "+FeatureIndexer+" is not available on the node")
+	}
+
+	ctxNode, ctxNodeCancel := context.WithTimeout(ctx, d.proxyTimeout)
+	indexer, err := d.nodeClient.Indexer(ctxNode)
+	ctxNodeCancel()
+	if err != nil {
+		if errors.Is(err, nodeclient.ErrIndexerPluginNotAvailable) {
+			return nil, echo.NewHTTPError(http.StatusNotFound, "feature "+FeatureIndexer+" is not available on the node")
+		}
+
+		return nil, err
+	}
+
+	cursorParas := nodeclient.IndexerCursorParas{PageSize: addressOutputsPageSize}
+	queries := []nodeclient.IndexerQuery{
+		&nodeclient.BasicOutputsQuery{IndexerCursorParas: cursorParas, AddressBech32: bech32Address},
+		&nodeclient.AliasesQuery{IndexerCursorParas: cursorParas, StateControllerBech32: bech32Address},
+		&nodeclient.NFTsQuery{IndexerCursorParas: cursorParas, AddressBech32: bech32Address},
+	}
+
+	aggregator := newAddressBalanceAggregator(bech32Address, prefix)
+	for _, query := range queries {
+		for {
+			done, err := d.addAddressOutputsPage(ctx, indexer, query, aggregator)
+			if err != nil {
+				// the indexer rejects addresses of other networks
+				return nil, nodeAPIError(err)
+			}
+			if done {
+				break
+			}
+		}
+	}
+
+	return aggregator.result(), nil
+}
+
+// addAddressOutputsPage queries the next page of the indexer query and adds its outputs to the aggregator.
+// Every page and the lookup of its outputs get their own deadline, the amount of pages depends on the address.
+// It returns true if there are no more pages or the maximum amount of outputs was reached.
+func (d *Dashboard) addAddressOutputsPage(ctx context.Context, indexer nodeclient.IndexerClient, query nodeclient.IndexerQuery, aggregator *addressBalanceAggregator) (bool, error) {
+	ctxNode, ctxNodeCancel := context.WithTimeout(ctx, d.proxyTimeout)
+	defer ctxNodeCancel()
+
+	// the result set continues at the cursor stored in the query, it is only used for a single page
+	resultSet, err := indexer.Outputs(ctxNode, query)
+	if err != nil {
+		return false, err
+	}
+
+	if !resultSet.Next() {
+		return true, resultSet.Error
+	}
+
+	if resultSet.Response.LedgerIndex > aggregator.balance.LedgerIndex {
+		aggregator.balance.LedgerIndex = resultSet.Response.LedgerIndex
+	}
+
+	outputs, err := resultSet.Outputs()
+	if err != nil {
+		return false, err
+	}
+
+	for i, outputID := range resultSet.Response.Items.MustOutputIDs() {
+		if !aggregator.add(outputID, outputs[i]) {
+			return true, nil
+		}
+	}
+
+	return resultSet.Response.Cursor == nil, nil
+}
+
+func (d *Dashboard) addressBalanceRoute(c echo.Context) error {
+	// the aggregation fans out to the indexer, therefore it uses the indexer budget
+	if err := d.checkRateLimit(c, &ProxyRoute{Feature: FeatureIndexer}); err != nil {
+		return err
+	}
+
+	balance, err := d.addressBalance(c.Request().Context(), c.Param(ParameterBech32Address))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, balance)
+}
@@ -0,0 +1,249 @@
+package dashboard
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/iota.go/v3/nodeclient"
+)
+
+func TestAddressBalanceAggregator(t *testing.T) {
+	var owner, sender iotago.Ed25519Address
+	owner[0] = 1
+	sender[0] = 2
+
+	var tokenA, tokenB iotago.NativeTokenID
+	tokenA[0] = 0xa
+	tokenB[0] = 0xb
+
+	basic := func(amount uint64, nativeTokens iotago.NativeTokens, conditions ...iotago.UnlockCondition) *iotago.BasicOutput {
+		return &iotago.BasicOutput{
+			Amount:       amount,
+			NativeTokens: nativeTokens,
+			Conditions:   append(iotago.UnlockConditions{&iotago.AddressUnlockCondition{Address: &owner}}, conditions...),
+		}
+	}
+
+	tests := []struct {
+		name             string
+		outputs          []iotago.Output
+		wantBaseTokens   uint64
+		wantNativeTokens []*AddressNativeTokenBalance
+		wantReturns      []*StorageDepositReturnObligation
+		wantKinds        [3]int
+	}{
+		{
+			name: "no outputs",
+		},
+		{
+			name: "output kinds",
+			outputs: []iotago.Output{
+				basic(100, nil),
+				&iotago.AliasOutput{Amount: 200},
+				&iotago.NFTOutput{Amount: 300},
+				basic(400, nil),
+			},
+			wantBaseTokens: 1000,
+			wantKinds:      [3]int{2, 1, 1},
+		},
+		{
+			name: "native tokens are summed up and sorted",
+			outputs: []iotago.Output{
+				basic(100, iotago.NativeTokens{{ID: tokenB, Amount: big.NewInt(5)}, {ID: tokenA, Amount: big.NewInt(1)}}),
+				basic(100, iotago.NativeTokens{{ID: tokenB, Amount: big.NewInt(10)}}),
+			},
+			wantBaseTokens: 200,
+			wantNativeTokens: []*AddressNativeTokenBalance{
+				{ID: tokenA.ToHex(), Amount: iotago.EncodeUint256(big.NewInt(1))},
+				{ID: tokenB.ToHex(), Amount: iotago.EncodeUint256(big.NewInt(15))},
+			},
+			wantKinds: [3]int{2, 0, 0},
+		},
+		{
+			name: "storage deposit returns",
+			outputs: []iotago.Output{
+				basic(100, nil, &iotago.StorageDepositReturnUnlockCondition{ReturnAddress: &sender, Amount: 40}),
+				basic(100, nil,
+					&iotago.StorageDepositReturnUnlockCondition{ReturnAddress: &sender, Amount: 60},
+					&iotago.ExpirationUnlockCondition{ReturnAddress: &sender, UnixTime: 1234},
+				),
+			},
+			wantBaseTokens: 200,
+			wantReturns: []*StorageDepositReturnObligation{
+				{ReturnAddress: sender.Bech32(iotago.PrefixTestnet), Amount: 40},
+				{ReturnAddress: sender.Bech32(iotago.PrefixTestnet), Amount: 60, ExpirationUnixTime: 1234},
+			},
+			wantKinds: [3]int{2, 0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aggregator := newAddressBalanceAggregator(owner.Bech32(iotago.PrefixTestnet), iotago.PrefixTestnet)
+
+			outputIDs := make([]iotago.OutputID, len(tt.outputs))
+			for i, output := range tt.outputs {
+				outputIDs[i][0] = byte(i)
+				if !aggregator.add(outputIDs[i], output) {
+					t.Fatalf("output %d was not added", i)
+				}
+			}
+
+			balance := aggregator.result()
+			if balance.Truncated {
+				t.Error("expected the balance not to be truncated")
+			}
+			if balance.BaseTokenBalance != tt.wantBaseTokens {
+				t.Errorf("expected %d base tokens, got %d", tt.wantBaseTokens, balance.BaseTokenBalance)
+			}
+
+			kinds := [3]int{len(balance.BasicOutputs), len(balance.AliasOutputs), len(balance.NFTOutputs)}
+			if kinds != tt.wantKinds {
+				t.Errorf("expected basic, alias and NFT outputs %v, got %v", tt.wantKinds, kinds)
+			}
+
+			if len(balance.NativeTokens) != len(tt.wantNativeTokens) {
+				t.Fatalf("expected %d native tokens, got %d", len(tt.wantNativeTokens), len(balance.NativeTokens))
+			}
+			for i, nativeToken := range balance.NativeTokens {
+				if *nativeToken != *tt.wantNativeTokens[i] {
+					t.Errorf("native token %d: expected %+v, got %+v", i, tt.wantNativeTokens[i], nativeToken)
+				}
+			}
+
+			var wantReturnAmount uint64
+			if len(balance.StorageDepositReturns) != len(tt.wantReturns) {
+				t.Fatalf("expected %d storage deposit returns, got %d", len(tt.wantReturns), len(balance.StorageDepositReturns))
+			}
+			for i, obligation := range balance.StorageDepositReturns {
+				want := *tt.wantReturns[i]
+				want.OutputID = outputIDs[i].ToHex()
+				if *obligation != want {
+					t.Errorf("storage deposit return %d: expected %+v, got %+v", i, want, obligation)
+				}
+				wantReturnAmount += want.Amount
+			}
+			if balance.StorageDepositReturnAmount != wantReturnAmount {
+				t.Errorf("expected a storage deposit return amount of %d, got %d", wantReturnAmount, balance.StorageDepositReturnAmount)
+			}
+		})
+	}
+}
+
+func TestAddressBalanceAggregatorTruncates(t *testing.T) {
+	var owner iotago.Ed25519Address
+	aggregator := newAddressBalanceAggregator(owner.Bech32(iotago.PrefixTestnet), iotago.PrefixTestnet)
+
+	output := &iotago.BasicOutput{
+		Amount:     1,
+		Conditions: iotago.UnlockConditions{&iotago.AddressUnlockCondition{Address: &owner}},
+	}
+
+	for i := 0; i < AddressMaxOutputs; i++ {
+		if !aggregator.add(iotago.OutputID{}, output) {
+			t.Fatalf("output %d was not added", i)
+		}
+	}
+	if aggregator.add(iotago.OutputID{}, output) {
+		t.Fatal("expected the output above the maximum not to be added")
+	}
+
+	balance := aggregator.result()
+	if !balance.Truncated {
+		t.Error("expected the balance to be truncated")
+	}
+	if balance.BaseTokenBalance != AddressMaxOutputs {
+		t.Errorf("expected %d base tokens, got %d", AddressMaxOutputs, balance.BaseTokenBalance)
+	}
+}
+
+func TestAddressBalanceErrors(t *testing.T) {
+	// newNode returns a node that serves the given plugins and rejects addresses of other networks than the testnet.
+	newNode := func(routes string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			switch {
+			case r.URL.Path == nodeclient.RouteRoutes:
+				_, _ = w.Write([]byte(`{"routes":[` + routes + `]}`))
+			case !strings.HasPrefix(r.URL.Query().Get("address"), string(iotago.PrefixTestnet)) && r.URL.Query().Get("address") != "":
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":{"code":"400","message":"invalid address"}}`))
+			case strings.HasPrefix(r.URL.Path, "/api/indexer/v1/outputs/"):
+				_, _ = w.Write([]byte(`{"ledgerIndex":10,"pageSize":100,"items":[]}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"error":{"code":"404","message":"not found"}}`))
+			}
+		}))
+	}
+
+	node := newNode(`"core/v2","indexer/v1"`)
+	defer node.Close()
+	nodeWithoutIndexer := newNode(`"core/v2"`)
+	defer nodeWithoutIndexer.Close()
+
+	tests := []struct {
+		name       string
+		node       *httptest.Server
+		address    string
+		wantStatus int
+	}{
+		{
+			name:    "address without outputs",
+			node:    node,
+			address: (&iotago.Ed25519Address{1}).Bech32(iotago.PrefixTestnet),
+		},
+		{
+			name:       "address of another network",
+			node:       node,
+			address:    (&iotago.Ed25519Address{1}).Bech32(iotago.PrefixMainnet),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid address",
+			node:       node,
+			address:    "address",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "indexer not available",
+			node:       nodeWithoutIndexer,
+			address:    (&iotago.Ed25519Address{1}).Bech32(iotago.PrefixTestnet),
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Dashboard{
+				nodeClient:   nodeclient.New(tt.node.URL),
+				nodeFeatures: NewNodeFeatures(),
+				proxyTimeout: time.Second,
+			}
+
+			balance, err := d.addressBalance(context.Background(), tt.address)
+			if tt.wantStatus != 0 {
+				if status := httpErrorStatus(err); status != tt.wantStatus {
+					t.Fatalf("expected status %d, got %v", tt.wantStatus, err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if balance.BaseTokenBalance != 0 || len(balance.BasicOutputs) != 0 {
+				t.Errorf("expected an empty balance, got %+v", balance)
+			}
+		})
+	}
+}
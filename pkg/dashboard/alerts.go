@@ -0,0 +1,129 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/runtime/timeutil"
+	"github.com/iotaledger/inx-dashboard/pkg/alerting"
+	"github.com/iotaledger/inx-dashboard/pkg/daemon"
+)
+
+const (
+	AlertRuleNodeUnsynced   = "node-unsynced"
+	AlertRulePeerCountBelow = "peer-count-below"
+	AlertRuleDatabaseGrowth = "database-growth"
+	AlertRuleMilestoneLag   = "milestone-lag"
+
+	// minDatabaseGrowthWindow is the minimum time span of database size metrics
+	// needed to extrapolate the database growth per hour.
+	minDatabaseGrowthWindow = 10 * time.Minute
+	// maxPeerHistoryAge is the maximum age of the peer history to evaluate the connected peers.
+	maxPeerHistoryAge = 1 * time.Minute
+)
+
+func (d *Dashboard) setupAlertRules() {
+	if d.alertsNodeUnsynced {
+		d.alertEngine.AddRule(AlertRuleNodeUnsynced, func() (bool, string, error) {
+			isSynced := d.nodeBridge.IsNodeAlmostSynced()
+			syncStatus := d.getSyncStatus()
+
+			return !isSynced, fmt.Sprintf("node synced: %t (CMI %d, LMI %d)", isSynced, syncStatus.CMI, syncStatus.LMI), nil
+		})
+	}
+
+	if d.alertsMinPeers > 0 {
+		d.alertEngine.AddRule(AlertRulePeerCountBelow, func() (bool, string, error) {
+			// the peers are polled by the peer metrics feed
+			connectedPeers, lastUpdate := d.peerHistory.ConnectedPeers()
+			if time.Since(lastUpdate) > maxPeerHistoryAge {
+				return false, "", errors.Errorf("peer infos were last updated at %s", lastUpdate.Format(time.RFC3339))
+			}
+
+			return connectedPeers < d.alertsMinPeers, fmt.Sprintf("%d connected peers (minimum %d)", connectedPeers, d.alertsMinPeers), nil
+		})
+	}
+
+	if d.alertsMaxDatabaseGrowthPerHour > 0 {
+		d.alertEngine.AddRule(AlertRuleDatabaseGrowth, func() (bool, string, error) {
+			growthPerHour, err := databaseGrowthPerHour(d.getCachedDatabaseSizeMetrics())
+			if err != nil {
+				return false, "", err
+			}
+
+			return growthPerHour > d.alertsMaxDatabaseGrowthPerHour, fmt.Sprintf("database growth of %d bytes per hour (maximum %d)", growthPerHour, d.alertsMaxDatabaseGrowthPerHour), nil
+		})
+	}
+
+	if d.alertsMaxMilestoneLag > 0 {
+		d.alertEngine.AddRule(AlertRuleMilestoneLag, func() (bool, string, error) {
+			syncStatus := d.getSyncStatus()
+
+			var lag uint32
+			if syncStatus.LMI > syncStatus.CMI {
+				lag = syncStatus.LMI - syncStatus.CMI
+			}
+
+			return lag > d.alertsMaxMilestoneLag, fmt.Sprintf("CMI %d is lagging %d milestones behind LMI %d (maximum %d)", syncStatus.CMI, lag, syncStatus.LMI, d.alertsMaxMilestoneLag), nil
+		})
+	}
+}
+
+// databaseGrowthPerHour extrapolates the database growth per hour
+// based on the database size metrics of the last hour.
+func databaseGrowthPerHour(metrics []*DatabaseSizesMetric) (int64, error) {
+	if len(metrics) < 2 {
+		return 0, errors.New("not enough database size metrics")
+	}
+
+	newest := metrics[len(metrics)-1]
+
+	oldest := newest
+	for i := len(metrics) - 2; i >= 0; i-- {
+		if newest.Time-metrics[i].Time > int64(time.Hour.Seconds()) {
+			break
+		}
+		oldest = metrics[i]
+	}
+
+	window := time.Duration(newest.Time-oldest.Time) * time.Second
+	if window < minDatabaseGrowthWindow {
+		return 0, errors.Errorf("database size metrics only span %v", window)
+	}
+
+	return int64(float64(newest.Total-oldest.Total) / window.Hours()), nil
+}
+
+func (d *Dashboard) runAlertsEngine() {
+	if !d.alertsEnabled {
+		return
+	}
+
+	if err := d.daemon.BackgroundWorker("Dashboard[Alerts]", func(ctx context.Context) {
+		d.setupAlertRules()
+
+		onAlertChanged := func(alert *alerting.Alert) {
+			d.LogInfof("alert %s is %s: %s", alert.Rule, alert.State, alert.Message)
+
+			ctxMsg, ctxMsgCancel := context.WithTimeout(ctx, d.websocketWriteTimeout)
+			defer ctxMsgCancel()
+
+			_ = d.hub.BroadcastMsg(ctxMsg, &Msg{Type: MsgTypeAlert, Data: alert})
+
+			// the delivery may take long because of retries, it must not block the evaluation
+			d.queueWebhook("alert "+alert.Rule, alert)
+		}
+
+		unhook := d.alertEngine.Events.AlertChanged.Hook(onAlertChanged).Unhook
+
+		ticker := timeutil.NewTicker(d.alertEngine.Evaluate, d.alertsCheckInterval, ctx)
+		ticker.WaitForGracefulShutdown()
+
+		unhook()
+	}, daemon.PriorityStopDashboard); err != nil {
+		d.LogPanicf("failed to start worker: %s", err)
+	}
+}
@@ -20,11 +20,57 @@ import (
 const (
 	WebsocketCmdRegister   = 0
 	WebsocketCmdUnregister = 1
+	// WebsocketCmdFilter sets the tag prefix filter of a topic.
+	// The 0x-prefixed hex encoded tag prefix follows the topic byte, an empty prefix removes the filter.
+	WebsocketCmdFilter = 2
+	// WebsocketCmdReplay starts a replay of confirmed milestone cones for the visualizer.
+	// The little endian encoded start index, end index and blocks per second (uint32 each) follow the topic byte.
+	// A new replay request cancels the running replay of the client.
+	WebsocketCmdReplay = 3
+	// WebsocketCmdHighlight requests the past and future cone of a block for the visualizer.
+	// The 0x-prefixed hex encoded block ID follows the topic byte.
+	WebsocketCmdHighlight = 4
+	// WebsocketCmdWatch replaces the blocks watched by the client.
+	// A comma separated list of 0x-prefixed hex encoded block IDs follows the topic byte, an empty list stops watching.
+	WebsocketCmdWatch = 5
 )
 
+// publicAPIRoutes are the dashboard HTTP REST routes which can be called without authorization.
+// The public node API routes are declared in the route registry.
+var publicAPIRoutes = []*apiRoute{
+	{Method: http.MethodGet, Path: RouteConflicts},
+	{Method: http.MethodGet, Path: RouteSearch},
+	{Method: http.MethodGet, Path: RouteBlockView},
+	{Method: http.MethodGet, Path: RouteTransactionView},
+	{Method: http.MethodGet, Path: RouteOpenAPI},
+}
+
+// isPublicAPIRoute returns whether the dashboard route can be called without authorization.
+func isPublicAPIRoute(method string, path string) bool {
+	for _, route := range publicAPIRoutes {
+		if route.Method == method && route.Path == path {
+			return true
+		}
+	}
+
+	return false
+}
+
+// loginRequest is the body of the auth route, either a JWT to renew or the credentials.
+type loginRequest struct {
+	JWT      string `json:"jwt,omitempty"`
+	User     string `json:"user,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// loginResponse is the response of the auth route.
+type loginResponse struct {
+	JWT string `json:"jwt"`
+}
+
 func compileRouteAsRegex(route string) *regexp.Regexp {
 
-	r := regexp.QuoteMeta(route)
+	r := "^" + regexp.QuoteMeta(route)
 	r = strings.ReplaceAll(r, `\*`, "(.*?)")
 	r += "$"
 
@@ -65,42 +111,24 @@ func (d *Dashboard) devModeReverseProxyMiddleware() echo.MiddlewareFunc {
 
 func (d *Dashboard) apiMiddlewares() []echo.MiddlewareFunc {
 
-	// the HTTP REST routes which can be called without authorization.
-	// Wildcards using * are allowed
-	publicRoutes := []string{
-		"/api/routes",
-		"/api/core/v2/info",
-		"/api/core/v2/blocks*",
-		"/api/core/v2/transactions*",
-		"/api/core/v2/milestones*",
-		"/api/core/v2/outputs*",
-		"/api/indexer/v1/*",
-	}
-
 	// the HTTP REST routes which need to be called with authorization.
 	// Wildcards using * are allowed
 	protectedRoutes := []string{
-		"/api/core/v2/peers*",
-		"/api/*",
+		DashboardAPIPath + "/core/v2/peers*",
+		DashboardAPIPath + "/*",
 	}
 
-	publicRoutesRegEx := compileRoutesAsRegexes(publicRoutes)
 	protectedRoutesRegEx := compileRoutesAsRegexes(protectedRoutes)
 
-	matchPublic := func(c echo.Context) bool {
-		loweredPath := strings.ToLower(c.Request().RequestURI)
-
-		for _, reg := range publicRoutesRegEx {
-			if reg.MatchString(loweredPath) {
-				return true
-			}
-		}
+	matchPublicProxyRoute := d.routeRegistry.publicRouteMatcher(DashboardAPIPath)
+	matchPublicAPIRoute := newRouteMatcher(DashboardAPIPath, publicAPIRoutes)
 
-		return false
+	matchPublic := func(c echo.Context) bool {
+		return matchPublicProxyRoute(c) || matchPublicAPIRoute(c)
 	}
 
 	matchProtected := func(c echo.Context) bool {
-		loweredPath := strings.ToLower(c.Request().RequestURI)
+		loweredPath := strings.ToLower(c.Request().URL.Path)
 
 		for _, reg := range protectedRoutesRegEx {
 			if reg.MatchString(loweredPath) {
@@ -131,12 +159,6 @@ func (d *Dashboard) apiMiddlewares() []echo.MiddlewareFunc {
 
 func (d *Dashboard) authRoute(c echo.Context) error {
 
-	type loginRequest struct {
-		JWT      string `json:"jwt"`
-		User     string `json:"user"`
-		Password string `json:"password"`
-	}
-
 	request := &loginRequest{}
 
 	if err := c.Bind(request); err != nil {
@@ -159,8 +181,8 @@ func (d *Dashboard) authRoute(c echo.Context) error {
 		return err
 	}
 
-	return c.JSON(http.StatusOK, map[string]string{
-		"jwt": t,
+	return c.JSON(http.StatusOK, &loginResponse{
+		JWT: t,
 	})
 }
 
@@ -182,7 +204,7 @@ func (d *Dashboard) setupRoutes(e *echo.Echo) {
 	e.Group("/dashboard/*").Use(mw)
 
 	// Pass all the dashboard request through to the local rest API
-	d.setupAPIRoutes(e.Group("/dashboard/api", d.apiMiddlewares()...))
+	d.setupAPIRoutes(e.Group(DashboardAPIPath, d.apiMiddlewares()...))
 
 	e.GET("/dashboard/ws", d.websocketRoute)
 
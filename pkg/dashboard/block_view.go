@@ -0,0 +1,675 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/inx-dashboard/pkg/common"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	NativeTokenMovementMinted      = "minted"
+	NativeTokenMovementMelted      = "melted"
+	NativeTokenMovementTransferred = "transferred"
+
+	// blockViewInputsTimeout is the time in which all inputs of a transaction have to be resolved.
+	blockViewInputsTimeout = 10 * time.Second
+	// blockViewInputsWorkers is the maximum amount of inputs of a transaction that are resolved at once.
+	blockViewInputsWorkers = 8
+)
+
+// BlockView is a decoded, human-oriented representation of a block.
+type BlockView struct {
+	BlockID         string   `json:"blockId"`
+	ProtocolVersion byte     `json:"protocolVersion"`
+	Parents         []string `json:"parents"`
+	Nonce           uint64   `json:"nonce,string"`
+	// PayloadType is the name of the payload type, empty if the block has no payload.
+	PayloadType string                `json:"payloadType"`
+	TaggedData  *TaggedDataView       `json:"taggedData,omitempty"`
+	Transaction *TransactionView      `json:"transaction,omitempty"`
+	Milestone   *MilestonePayloadView `json:"milestone,omitempty"`
+}
+
+// TaggedDataView is the decoded tagged data payload.
+type TaggedDataView struct {
+	Tag string `json:"tag"`
+	// TagText is the tag as text if it only contains printable characters.
+	TagText string `json:"tagText,omitempty"`
+	Data    string `json:"data"`
+	// DataText is the data as text if it only contains printable characters.
+	DataText string `json:"dataText,omitempty"`
+}
+
+// TransactionView is the decoded transaction payload with its resolved inputs.
+type TransactionView struct {
+	TransactionID        string                 `json:"transactionId"`
+	NetworkID            uint64                 `json:"networkId,string"`
+	InputsCommitment     string                 `json:"inputsCommitment"`
+	Inputs               []*InputView           `json:"inputs"`
+	Outputs              []*OutputView          `json:"outputs"`
+	Unlocks              []*ExplainedItem       `json:"unlocks"`
+	NativeTokenMovements []*NativeTokenMovement `json:"nativeTokenMovements"`
+	// InputsResolved is false if not all inputs could be resolved, the native token movements are incomplete in that case.
+	InputsResolved bool            `json:"inputsResolved"`
+	TaggedData     *TaggedDataView `json:"taggedData,omitempty"`
+}
+
+// InputView is an input of a transaction together with the output it consumes.
+type InputView struct {
+	OutputID string `json:"outputId"`
+	// Output is the consumed output, nil if it could not be resolved.
+	Output *OutputView `json:"output,omitempty"`
+	// Error is set if the consumed output could not be resolved.
+	Error string `json:"error,omitempty"`
+}
+
+// OutputView is a decoded output with its unlock conditions and features explained.
+type OutputView struct {
+	OutputID string `json:"outputId"`
+	Type     string `json:"type"`
+	Amount   uint64 `json:"amount,string"`
+	// ChainID is the alias ID, NFT ID or foundry ID of chain constrained outputs.
+	ChainID           string                       `json:"chainId,omitempty"`
+	NativeTokens      []*AddressNativeTokenBalance `json:"nativeTokens"`
+	UnlockConditions  []*ExplainedItem             `json:"unlockConditions"`
+	Features          []*ExplainedItem             `json:"features"`
+	ImmutableFeatures []*ExplainedItem             `json:"immutableFeatures"`
+	// Details holds output type specific fields like the state index of alias outputs or the token scheme of foundries.
+	Details []*ExplainedItem `json:"details,omitempty"`
+}
+
+// ExplainedItem is a typed element (unlock condition, feature, unlock, ...) with a human readable description.
+type ExplainedItem struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+// NativeTokenMovement is the change of the supply of a native token by a transaction.
+type NativeTokenMovement struct {
+	ID string `json:"id"`
+	// Input and Output are the hex encoded uint256 sums of the native token in the inputs and outputs.
+	Input  string `json:"input"`
+	Output string `json:"output"`
+	// Delta is the signed decimal difference between the outputs and the inputs.
+	Delta string `json:"delta"`
+	Kind  string `json:"kind"`
+}
+
+// MilestonePayloadView is the decoded milestone payload.
+type MilestonePayloadView struct {
+	MilestoneID         string                    `json:"milestoneId"`
+	Index               iotago.MilestoneIndex     `json:"index"`
+	Timestamp           string                    `json:"timestamp"`
+	PreviousMilestoneID string                    `json:"previousMilestoneId"`
+	Parents             []string                  `json:"parents"`
+	InclusionMerkleRoot string                    `json:"inclusionMerkleRoot"`
+	AppliedMerkleRoot   string                    `json:"appliedMerkleRoot"`
+	Metadata            string                    `json:"metadata,omitempty"`
+	Signatures          []*MilestoneSignatureView `json:"signatures"`
+	Receipt             *ReceiptView              `json:"receipt,omitempty"`
+	ProtocolParameters  *ExplainedItem            `json:"protocolParameters,omitempty"`
+}
+
+// MilestoneSignatureView is a signature of a milestone payload.
+type MilestoneSignatureView struct {
+	PublicKey string `json:"publicKey"`
+	Signature string `json:"signature"`
+}
+
+// ReceiptView is the decoded receipt of funds migrated from the legacy network.
+type ReceiptView struct {
+	MigratedAt    iotago.MilestoneIndex `json:"migratedAt"`
+	Final         bool                  `json:"final"`
+	Funds         []*ExplainedItem      `json:"funds"`
+	TotalDeposit  uint64                `json:"totalDeposit,string"`
+	TreasuryInput string                `json:"treasuryInput,omitempty"`
+	// TreasuryOutput is the remaining amount in the treasury.
+	TreasuryOutput uint64 `json:"treasuryOutput,string"`
+}
+
+// printableText returns the data as text if it is valid UTF-8 and only contains printable characters.
+func printableText(data []byte) string {
+	if len(data) == 0 || !utf8.Valid(data) {
+		return ""
+	}
+
+	text := string(data)
+	for _, r := range text {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return ""
+		}
+	}
+
+	return text
+}
+
+func formatUnixTime(unixTime uint32) string {
+	return time.Unix(int64(unixTime), 0).UTC().Format(time.RFC3339)
+}
+
+// blockViewDecoder decodes blocks and resolves the inputs of their transactions.
+type blockViewDecoder struct {
+	ctx    context.Context
+	d      *Dashboard
+	prefix iotago.NetworkPrefix
+}
+
+func (b *blockViewDecoder) taggedData(taggedData *iotago.TaggedData) *TaggedDataView {
+	return &TaggedDataView{
+		Tag:      iotago.EncodeHex(taggedData.Tag),
+		TagText:  printableText(taggedData.Tag),
+		Data:     iotago.EncodeHex(taggedData.Data),
+		DataText: printableText(taggedData.Data),
+	}
+}
+
+func (b *blockViewDecoder) block(blockID iotago.BlockID, block *iotago.Block) (*BlockView, error) {
+	view := &BlockView{
+		BlockID:         blockID.ToHex(),
+		ProtocolVersion: block.ProtocolVersion,
+		Parents:         block.Parents.ToHex(),
+		Nonce:           block.Nonce,
+	}
+
+	if block.Payload == nil {
+		return view, nil
+	}
+	view.PayloadType = block.Payload.PayloadType().String()
+
+	switch payload := block.Payload.(type) {
+	case *iotago.TaggedData:
+		view.TaggedData = b.taggedData(payload)
+
+	case *iotago.Transaction:
+		transaction, err := b.transaction(payload)
+		if err != nil {
+			return nil, err
+		}
+		view.Transaction = transaction
+
+	case *iotago.Milestone:
+		milestone, err := b.milestone(payload)
+		if err != nil {
+			return nil, err
+		}
+		view.Milestone = milestone
+	}
+
+	return view, nil
+}
+
+func (b *blockViewDecoder) transaction(transaction *iotago.Transaction) (*TransactionView, error) {
+	transactionID, err := transaction.ID()
+	if err != nil {
+		return nil, err
+	}
+
+	essence := transaction.Essence
+	view := &TransactionView{
+		TransactionID:        transactionID.ToHex(),
+		NetworkID:            essence.NetworkID,
+		InputsCommitment:     iotago.EncodeHex(essence.InputsCommitment[:]),
+		Inputs:               make([]*InputView, 0, len(essence.Inputs)),
+		Outputs:              make([]*OutputView, 0, len(essence.Outputs)),
+		Unlocks:              make([]*ExplainedItem, 0, len(transaction.Unlocks)),
+		NativeTokenMovements: make([]*NativeTokenMovement, 0),
+		InputsResolved:       true,
+	}
+
+	if taggedData, ok := essence.Payload.(*iotago.TaggedData); ok {
+		view.TaggedData = b.taggedData(taggedData)
+	}
+
+	inputIDs := make(iotago.OutputIDs, 0, len(essence.Inputs))
+	for _, input := range essence.Inputs {
+		if utxoInput, ok := input.(*iotago.UTXOInput); ok {
+			inputIDs = append(inputIDs, utxoInput.ID())
+		}
+	}
+	inputs, inputErrs := b.resolveOutputs(inputIDs)
+
+	inputSums := make(iotago.NativeTokenSum)
+	for i, outputID := range inputIDs {
+		inputView := &InputView{OutputID: outputID.ToHex()}
+		view.Inputs = append(view.Inputs, inputView)
+
+		if inputErrs[i] != nil {
+			inputView.Error = inputErrs[i].Error()
+			view.InputsResolved = false
+
+			continue
+		}
+
+		output := inputs[i]
+
+		inputView.Output = b.output(outputID, output)
+		addNativeTokens(inputSums, output.NativeTokenList())
+	}
+
+	outputSums := make(iotago.NativeTokenSum)
+	for index, output := range essence.Outputs {
+		outputID := iotago.OutputIDFromTransactionIDAndIndex(transactionID, uint16(index))
+		view.Outputs = append(view.Outputs, b.output(outputID, output))
+		addNativeTokens(outputSums, output.NativeTokenList())
+	}
+
+	for index, unlock := range transaction.Unlocks {
+		view.Unlocks = append(view.Unlocks, b.unlock(index, unlock))
+	}
+
+	if view.InputsResolved {
+		view.NativeTokenMovements = nativeTokenMovements(inputSums, outputSums)
+	}
+
+	return view, nil
+}
+
+// resolveOutputs looks up the outputs in the node, a few at once and all of them within blockViewInputsTimeout.
+// The returned outputs and errors have the same order as the given IDs.
+func (b *blockViewDecoder) resolveOutputs(outputIDs iotago.OutputIDs) ([]iotago.Output, []error) {
+	outputs := make([]iotago.Output, len(outputIDs))
+	errs := make([]error, len(outputIDs))
+
+	ctxInputs, ctxInputsCancel := context.WithTimeout(b.ctx, blockViewInputsTimeout)
+	defer ctxInputsCancel()
+
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, blockViewInputsWorkers)
+	for i, outputID := range outputIDs {
+		select {
+		case workers <- struct{}{}:
+		case <-ctxInputs.Done():
+			errs[i] = ctxInputs.Err()
+
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, outputID iotago.OutputID) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+
+			ctxNode, ctxNodeCancel := context.WithTimeout(ctxInputs, nodeTimeout)
+			defer ctxNodeCancel()
+
+			outputs[i], errs[i] = b.d.nodeClient.OutputByID(ctxNode, outputID)
+		}(i, outputID)
+	}
+	wg.Wait()
+
+	return outputs, errs
+}
+
+func addNativeTokens(sums iotago.NativeTokenSum, nativeTokens iotago.NativeTokens) {
+	for _, nativeToken := range nativeTokens {
+		sum, exists := sums[nativeToken.ID]
+		if !exists {
+			sum = new(big.Int)
+			sums[nativeToken.ID] = sum
+		}
+		sum.Add(sum, nativeToken.Amount)
+	}
+}
+
+// nativeTokenMovements compares the native token sums of the inputs and outputs.
+// A surplus in the outputs was minted, a deficit was melted (or burned).
+func nativeTokenMovements(inputSums iotago.NativeTokenSum, outputSums iotago.NativeTokenSum) []*NativeTokenMovement {
+	ids := make(map[iotago.NativeTokenID]struct{})
+	for id := range inputSums {
+		ids[id] = struct{}{}
+	}
+	for id := range outputSums {
+		ids[id] = struct{}{}
+	}
+
+	movements := make([]*NativeTokenMovement, 0, len(ids))
+	for id := range ids {
+		input, output := new(big.Int), new(big.Int)
+		if sum, exists := inputSums[id]; exists {
+			input = sum
+		}
+		if sum, exists := outputSums[id]; exists {
+			output = sum
+		}
+
+		delta := new(big.Int).Sub(output, input)
+
+		kind := NativeTokenMovementTransferred
+		switch delta.Sign() {
+		case 1:
+			kind = NativeTokenMovementMinted
+		case -1:
+			kind = NativeTokenMovementMelted
+		}
+
+		movements = append(movements, &NativeTokenMovement{
+			ID:     id.ToHex(),
+			Input:  iotago.EncodeUint256(input),
+			Output: iotago.EncodeUint256(output),
+			Delta:  delta.String(),
+			Kind:   kind,
+		})
+	}
+
+	sort.Slice(movements, func(i, j int) bool {
+		return movements[i].ID < movements[j].ID
+	})
+
+	return movements
+}
+
+func (b *blockViewDecoder) output(outputID iotago.OutputID, output iotago.Output) *OutputView {
+	view := &OutputView{
+		OutputID:          outputID.ToHex(),
+		Type:              output.Type().String(),
+		Amount:            output.Deposit(),
+		NativeTokens:      make([]*AddressNativeTokenBalance, 0),
+		UnlockConditions:  b.unlockConditions(output.UnlockConditionSet()),
+		Features:          b.features(output.FeatureSet()),
+		ImmutableFeatures: make([]*ExplainedItem, 0),
+	}
+
+	for _, nativeToken := range output.NativeTokenList() {
+		view.NativeTokens = append(view.NativeTokens, &AddressNativeTokenBalance{
+			ID:     nativeToken.ID.ToHex(),
+			Amount: iotago.EncodeUint256(nativeToken.Amount),
+		})
+	}
+
+	switch o := output.(type) {
+	case *iotago.AliasOutput:
+		aliasID := o.AliasID
+		if aliasID.Empty() {
+			// the alias is created by this output
+			aliasID = iotago.AliasIDFromOutputID(outputID)
+		}
+		view.ChainID = aliasID.ToHex()
+		view.ImmutableFeatures = b.features(o.ImmutableFeatureSet())
+		view.Details = []*ExplainedItem{
+			{Type: "AliasAddress", Description: aliasID.ToAddress().Bech32(b.prefix)},
+			{Type: "StateIndex", Description: fmt.Sprintf("%d", o.StateIndex)},
+			{Type: "FoundryCounter", Description: fmt.Sprintf("%d", o.FoundryCounter)},
+		}
+		if len(o.StateMetadata) > 0 {
+			view.Details = append(view.Details, &ExplainedItem{Type: "StateMetadata", Description: iotago.EncodeHex(o.StateMetadata)})
+		}
+
+	case *iotago.NFTOutput:
+		nftID := o.NFTID
+		if nftID.Empty() {
+			// the NFT is minted by this output
+			nftID = iotago.NFTIDFromOutputID(outputID)
+		}
+		view.ChainID = nftID.ToHex()
+		view.ImmutableFeatures = b.features(o.ImmutableFeatureSet())
+		view.Details = []*ExplainedItem{
+			{Type: "NFTAddress", Description: nftID.ToAddress().Bech32(b.prefix)},
+		}
+
+	case *iotago.FoundryOutput:
+		if foundryID, err := o.ID(); err == nil {
+			view.ChainID = foundryID.ToHex()
+		}
+		view.ImmutableFeatures = b.features(o.ImmutableFeatureSet())
+		view.Details = []*ExplainedItem{
+			{Type: "SerialNumber", Description: fmt.Sprintf("%d", o.SerialNumber)},
+		}
+		if tokenScheme, ok := o.TokenScheme.(*iotago.SimpleTokenScheme); ok {
+			view.Details = append(view.Details, &ExplainedItem{
+				Type: "SimpleTokenScheme",
+				Description: fmt.Sprintf("minted %s, melted %s, maximum supply %s",
+					tokenScheme.MintedTokens.String(), tokenScheme.MeltedTokens.String(), tokenScheme.MaximumSupply.String()),
+			})
+		}
+	}
+
+	return view
+}
+
+func (b *blockViewDecoder) unlockConditions(unlockConditions iotago.UnlockConditionSet) []*ExplainedItem {
+	types := make([]iotago.UnlockConditionType, 0, len(unlockConditions))
+	for unlockConditionType := range unlockConditions {
+		types = append(types, unlockConditionType)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+
+	items := make([]*ExplainedItem, 0, len(types))
+	for _, unlockConditionType := range types {
+		var description string
+
+		switch condition := unlockConditions[unlockConditionType].(type) {
+		case *iotago.AddressUnlockCondition:
+			description = fmt.Sprintf("can be unlocked by %s", condition.Address.Bech32(b.prefix))
+		case *iotago.StorageDepositReturnUnlockCondition:
+			description = fmt.Sprintf("%d base tokens have to be returned to %s when the output is consumed", condition.Amount, condition.ReturnAddress.Bech32(b.prefix))
+		case *iotago.TimelockUnlockCondition:
+			description = fmt.Sprintf("can not be unlocked before %s", formatUnixTime(condition.UnixTime))
+		case *iotago.ExpirationUnlockCondition:
+			description = fmt.Sprintf("can only be unlocked by %s from %s on", condition.ReturnAddress.Bech32(b.prefix), formatUnixTime(condition.UnixTime))
+		case *iotago.StateControllerAddressUnlockCondition:
+			description = fmt.Sprintf("state transitions can be performed by %s", condition.Address.Bech32(b.prefix))
+		case *iotago.GovernorAddressUnlockCondition:
+			description = fmt.Sprintf("governance transitions can be performed by %s", condition.Address.Bech32(b.prefix))
+		case *iotago.ImmutableAliasUnlockCondition:
+			description = fmt.Sprintf("controlled by the alias %s", condition.Address.Bech32(b.prefix))
+		}
+
+		items = append(items, &ExplainedItem{Type: unlockConditionType.String(), Description: description})
+	}
+
+	return items
+}
+
+func (b *blockViewDecoder) features(features iotago.FeatureSet) []*ExplainedItem {
+	types := make([]iotago.FeatureType, 0, len(features))
+	for featureType := range features {
+		types = append(types, featureType)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+
+	items := make([]*ExplainedItem, 0, len(types))
+	for _, featureType := range types {
+		var description string
+
+		switch feature := features[featureType].(type) {
+		case *iotago.SenderFeature:
+			description = fmt.Sprintf("sent by %s", feature.Address.Bech32(b.prefix))
+		case *iotago.IssuerFeature:
+			description = fmt.Sprintf("issued by %s", feature.Address.Bech32(b.prefix))
+		case *iotago.MetadataFeature:
+			description = iotago.EncodeHex(feature.Data)
+			if text := printableText(feature.Data); text != "" {
+				description = text
+			}
+		case *iotago.TagFeature:
+			description = iotago.EncodeHex(feature.Tag)
+			if text := printableText(feature.Tag); text != "" {
+				description = text
+			}
+		}
+
+		items = append(items, &ExplainedItem{Type: featureType.String(), Description: description})
+	}
+
+	return items
+}
+
+func (b *blockViewDecoder) unlock(index int, unlock iotago.Unlock) *ExplainedItem {
+	var description string
+
+	switch u := unlock.(type) {
+	case *iotago.SignatureUnlock:
+		if signature, ok := u.Signature.(*iotago.Ed25519Signature); ok {
+			description = fmt.Sprintf("input %d is unlocked by the Ed25519 signature of %s", index, iotago.EncodeHex(signature.PublicKey[:]))
+		}
+	case *iotago.ReferenceUnlock:
+		description = fmt.Sprintf("input %d is unlocked by the same signature as input %d", index, u.Reference)
+	case *iotago.AliasUnlock:
+		description = fmt.Sprintf("input %d is unlocked by the alias consumed in input %d", index, u.Reference)
+	case *iotago.NFTUnlock:
+		description = fmt.Sprintf("input %d is unlocked by the NFT consumed in input %d", index, u.Reference)
+	}
+
+	return &ExplainedItem{Type: unlock.Type().String(), Description: description}
+}
+
+func (b *blockViewDecoder) milestone(milestone *iotago.Milestone) (*MilestonePayloadView, error) {
+	milestoneID, err := milestone.ID()
+	if err != nil {
+		return nil, err
+	}
+
+	view := &MilestonePayloadView{
+		MilestoneID:         milestoneID.ToHex(),
+		Index:               milestone.Index,
+		Timestamp:           formatUnixTime(milestone.Timestamp),
+		PreviousMilestoneID: milestone.PreviousMilestoneID.ToHex(),
+		Parents:             milestone.Parents.ToHex(),
+		InclusionMerkleRoot: iotago.EncodeHex(milestone.InclusionMerkleRoot[:]),
+		AppliedMerkleRoot:   iotago.EncodeHex(milestone.AppliedMerkleRoot[:]),
+		Signatures:          make([]*MilestoneSignatureView, 0, len(milestone.Signatures)),
+	}
+
+	if len(milestone.Metadata) > 0 {
+		view.Metadata = iotago.EncodeHex(milestone.Metadata)
+	}
+
+	for _, signature := range milestone.Signatures {
+		if ed25519Signature, ok := signature.(*iotago.Ed25519Signature); ok {
+			view.Signatures = append(view.Signatures, &MilestoneSignatureView{
+				PublicKey: iotago.EncodeHex(ed25519Signature.PublicKey[:]),
+				Signature: iotago.EncodeHex(ed25519Signature.Signature[:]),
+			})
+		}
+	}
+
+	for _, opt := range milestone.Opts {
+		switch o := opt.(type) {
+		case *iotago.ReceiptMilestoneOpt:
+			view.Receipt = b.receipt(o)
+
+		case *iotago.ProtocolParamsMilestoneOpt:
+			view.ProtocolParameters = &ExplainedItem{
+				Type:        "ProtocolParamsMilestoneOpt",
+				Description: fmt.Sprintf("protocol version %d becomes active at milestone %d", o.ProtocolVersion, o.TargetMilestoneIndex),
+			}
+		}
+	}
+
+	return view, nil
+}
+
+func (b *blockViewDecoder) receipt(receipt *iotago.ReceiptMilestoneOpt) *ReceiptView {
+	view := &ReceiptView{
+		MigratedAt: receipt.MigratedAt,
+		Final:      receipt.Final,
+		Funds:      make([]*ExplainedItem, 0, len(receipt.Funds)),
+	}
+
+	for _, entry := range receipt.Funds {
+		view.TotalDeposit += entry.Deposit
+		view.Funds = append(view.Funds, &ExplainedItem{
+			Type:        "MigratedFundsEntry",
+			Description: fmt.Sprintf("%d base tokens migrated to %s by legacy bundle %s", entry.Deposit, entry.Address.Bech32(b.prefix), iotago.EncodeHex(entry.TailTransactionHash[:])),
+		})
+	}
+
+	if receipt.Transaction != nil {
+		if receipt.Transaction.Input != nil {
+			view.TreasuryInput = iotago.EncodeHex(receipt.Transaction.Input[:])
+		}
+		if receipt.Transaction.Output != nil {
+			view.TreasuryOutput = receipt.Transaction.Output.Amount
+		}
+	}
+
+	return view
+}
+
+func (d *Dashboard) newBlockViewDecoder(ctx context.Context) *blockViewDecoder {
+	return &blockViewDecoder{
+		ctx:    ctx,
+		d:      d,
+		prefix: d.nodeBridge.ProtocolParameters().Bech32HRP,
+	}
+}
+
+func (d *Dashboard) blockViewRoute(c echo.Context) error {
+	// the view causes a lookup of every input in the node, therefore it uses the core API budget
+	if err := d.checkRateLimit(c, &ProxyRoute{Feature: FeatureCoreAPI}); err != nil {
+		return err
+	}
+
+	blockID, err := iotago.BlockIDFromHexString(c.Param(ParameterBlockID))
+	if err != nil {
+		return errors.WithMessagef(common.ErrInvalidParameter, "invalid block ID: %s, error: %s", c.Param(ParameterBlockID), err)
+	}
+
+	block, err := d.getBlock(c.Request().Context(), blockID)
+	if err != nil {
+		if isNotFoundError(err) {
+			return errors.WithMessagef(common.ErrNotFound, "block not found: %s", blockID.ToHex())
+		}
+
+		return err
+	}
+
+	view, err := d.newBlockViewDecoder(c.Request().Context()).block(blockID, block)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, view)
+}
+
+func (d *Dashboard) transactionViewRoute(c echo.Context) error {
+	if err := d.checkRateLimit(c, &ProxyRoute{Feature: FeatureCoreAPI}); err != nil {
+		return err
+	}
+
+	transactionIDBytes, err := iotago.DecodeHex(c.Param(ParameterTransactionID))
+	if err != nil || len(transactionIDBytes) != iotago.TransactionIDLength {
+		return errors.WithMessagef(common.ErrInvalidParameter, "invalid transaction ID: %s", c.Param(ParameterTransactionID))
+	}
+
+	transactionID := iotago.TransactionID{}
+	copy(transactionID[:], transactionIDBytes)
+
+	ctxNode, ctxNodeCancel := context.WithTimeout(c.Request().Context(), nodeTimeout)
+	defer ctxNodeCancel()
+
+	block, err := d.nodeClient.TransactionIncludedBlock(ctxNode, transactionID, d.nodeBridge.ProtocolParameters())
+	if err != nil {
+		if isNotFoundError(err) {
+			return errors.WithMessagef(common.ErrNotFound, "transaction not found: %s", transactionID.ToHex())
+		}
+
+		return err
+	}
+
+	blockID, err := block.ID()
+	if err != nil {
+		return err
+	}
+
+	view, err := d.newBlockViewDecoder(c.Request().Context()).block(blockID, block)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, view)
+}
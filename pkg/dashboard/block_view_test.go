@@ -0,0 +1,168 @@
+package dashboard
+
+import (
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/iota.go/v3/nodeclient"
+)
+
+func TestNativeTokenMovements(t *testing.T) {
+	var tokenA, tokenB iotago.NativeTokenID
+	tokenA[0] = 0xa
+	tokenB[0] = 0xb
+
+	// sums builds the native token sums of the given IDs and amounts.
+	sums := func(nativeTokens ...*iotago.NativeToken) iotago.NativeTokenSum {
+		s := make(iotago.NativeTokenSum)
+		addNativeTokens(s, nativeTokens)
+
+		return s
+	}
+
+	movement := func(id iotago.NativeTokenID, input int64, output int64, delta string, kind string) *NativeTokenMovement {
+		return &NativeTokenMovement{
+			ID:     id.ToHex(),
+			Input:  iotago.EncodeUint256(big.NewInt(input)),
+			Output: iotago.EncodeUint256(big.NewInt(output)),
+			Delta:  delta,
+			Kind:   kind,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		inputs  iotago.NativeTokenSum
+		outputs iotago.NativeTokenSum
+		want    []*NativeTokenMovement
+	}{
+		{
+			name:    "no native tokens",
+			inputs:  sums(),
+			outputs: sums(),
+			want:    []*NativeTokenMovement{},
+		},
+		{
+			name:    "transferred",
+			inputs:  sums(&iotago.NativeToken{ID: tokenA, Amount: big.NewInt(10)}),
+			outputs: sums(&iotago.NativeToken{ID: tokenA, Amount: big.NewInt(4)}, &iotago.NativeToken{ID: tokenA, Amount: big.NewInt(6)}),
+			want:    []*NativeTokenMovement{movement(tokenA, 10, 10, "0", NativeTokenMovementTransferred)},
+		},
+		{
+			name:    "minted",
+			inputs:  sums(),
+			outputs: sums(&iotago.NativeToken{ID: tokenA, Amount: big.NewInt(100)}),
+			want:    []*NativeTokenMovement{movement(tokenA, 0, 100, "100", NativeTokenMovementMinted)},
+		},
+		{
+			name:    "melted",
+			inputs:  sums(&iotago.NativeToken{ID: tokenA, Amount: big.NewInt(100)}),
+			outputs: sums(&iotago.NativeToken{ID: tokenA, Amount: big.NewInt(30)}),
+			want:    []*NativeTokenMovement{movement(tokenA, 100, 30, "-70", NativeTokenMovementMelted)},
+		},
+		{
+			name:    "multiple tokens sorted by ID",
+			inputs:  sums(&iotago.NativeToken{ID: tokenB, Amount: big.NewInt(5)}),
+			outputs: sums(&iotago.NativeToken{ID: tokenB, Amount: big.NewInt(5)}, &iotago.NativeToken{ID: tokenA, Amount: big.NewInt(1)}),
+			want: []*NativeTokenMovement{
+				movement(tokenA, 0, 1, "1", NativeTokenMovementMinted),
+				movement(tokenB, 5, 5, "0", NativeTokenMovementTransferred),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			movements := nativeTokenMovements(tt.inputs, tt.outputs)
+
+			if len(movements) != len(tt.want) {
+				t.Fatalf("expected %d movements, got %d", len(tt.want), len(movements))
+			}
+			for i, m := range movements {
+				if *m != *tt.want[i] {
+					t.Errorf("movement %d: expected %+v, got %+v", i, tt.want[i], m)
+				}
+			}
+		})
+	}
+}
+
+func TestBlockViewRoutes(t *testing.T) {
+	blockID, block := testBlock(1, iotago.EmptyBlockID())
+	unknownBlockID, _ := testBlock(2, iotago.EmptyBlockID())
+
+	// the node does not know any transaction
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"code":"404","message":"transaction not found"}}`))
+	}))
+	defer node.Close()
+
+	d := New(nil, nil, newTestNodeBridge(t, &testINXServer{
+		blocks: map[iotago.BlockID]*iotago.Block{blockID: block},
+	}), nil)
+	d.nodeClient = nodeclient.New(node.URL)
+
+	tests := []struct {
+		name       string
+		route      func(c echo.Context) error
+		param      string
+		value      string
+		wantStatus int
+	}{
+		{
+			name:       "known block",
+			route:      d.blockViewRoute,
+			param:      ParameterBlockID,
+			value:      blockID.ToHex(),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "unknown block",
+			route:      d.blockViewRoute,
+			param:      ParameterBlockID,
+			value:      unknownBlockID.ToHex(),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "invalid block ID",
+			route:      d.blockViewRoute,
+			param:      ParameterBlockID,
+			value:      "0xzz",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown transaction",
+			route:      d.transactionViewRoute,
+			param:      ParameterTransactionID,
+			value:      iotago.TransactionID{1}.ToHex(),
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+			c.SetParamNames(tt.param)
+			c.SetParamValues(tt.value)
+
+			status := rec.Code
+			if err := tt.route(c); err != nil {
+				if status = httpErrorStatus(err); status == 0 {
+					t.Fatalf("expected an HTTP error, got %v", err)
+				}
+			}
+
+			if status != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, status)
+			}
+		})
+	}
+}
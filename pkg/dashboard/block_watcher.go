@@ -0,0 +1,273 @@
+package dashboard
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/lo"
+	"github.com/iotaledger/hive.go/web/websockethub"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	"github.com/iotaledger/inx-dashboard/pkg/common"
+	"github.com/iotaledger/inx-dashboard/pkg/daemon"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	// BlockWatcherMaxBlocksPerClient is the maximum amount of blocks a single client can watch.
+	BlockWatcherMaxBlocksPerClient = 100
+	// BlockWatcherMaxBlocks is the maximum amount of blocks watched by all clients together.
+	// The metadata of every watched block is queried with each confirmed milestone.
+	BlockWatcherMaxBlocks = 10000
+)
+
+// BlockStatus holds the status of a watched block.
+// Blocks are no longer watched once they were referenced by a milestone.
+type BlockStatus struct {
+	BlockID                    string `json:"blockId"`
+	IsSolid                    bool   `json:"isSolid"`
+	IsReferenced               bool   `json:"isReferenced"`
+	ReferencedByMilestoneIndex uint32 `json:"referencedByMilestoneIndex,omitempty"`
+	IsConflicting              bool   `json:"isConflicting"`
+	ConflictReason             int32  `json:"conflictReason,omitempty"`
+	ShouldPromote              bool   `json:"shouldPromote"`
+	ShouldReattach             bool   `json:"shouldReattach"`
+}
+
+func blockStatusFromBlockMetadata(blockMeta *BlockMetadata) *BlockStatus {
+	return &BlockStatus{
+		BlockID:                    blockMeta.BlockID.ToHex(),
+		IsSolid:                    blockMeta.IsSolid,
+		IsReferenced:               blockMeta.IsReferenced,
+		ReferencedByMilestoneIndex: blockMeta.ReferencedByMilestoneIndex,
+		IsConflicting:              blockMeta.IsConflicting,
+		ConflictReason:             int32(blockMeta.ConflictReason),
+		ShouldPromote:              blockMeta.ShouldPromote,
+		ShouldReattach:             blockMeta.ShouldReattach,
+	}
+}
+
+// parseBlockIDs parses a comma separated list of 0x-prefixed hex encoded block IDs.
+func parseBlockIDs(data string) (iotago.BlockIDs, error) {
+	blockIDs := iotago.BlockIDs{}
+	if strings.TrimSpace(data) == "" {
+		return blockIDs, nil
+	}
+
+	for _, blockIDHex := range strings.Split(data, ",") {
+		blockID, err := iotago.BlockIDFromHexString(strings.TrimSpace(blockIDHex))
+		if err != nil {
+			return nil, errors.WithMessagef(common.ErrInvalidParameter, "invalid block ID: %s, error: %s", blockIDHex, err)
+		}
+		blockIDs = append(blockIDs, blockID)
+	}
+
+	if len(blockIDs) > BlockWatcherMaxBlocksPerClient {
+		return nil, errors.WithMessagef(common.ErrInvalidParameter, "too many block IDs: %d, max. %d", len(blockIDs), BlockWatcherMaxBlocksPerClient)
+	}
+
+	return blockIDs, nil
+}
+
+type blockWatch struct {
+	send   func(status *BlockStatus)
+	blocks map[iotago.BlockID]*BlockStatus
+}
+
+// blockStatusUpdate is a status change that needs to be sent to a client.
+type blockStatusUpdate struct {
+	send   func(status *BlockStatus)
+	status *BlockStatus
+}
+
+// BlockWatcher keeps track of the blocks watched by the websocket clients.
+type BlockWatcher struct {
+	sync.RWMutex
+
+	watches map[websockethub.ClientID]*blockWatch
+	// count is the amount of blocks watched by all clients.
+	count int
+}
+
+func NewBlockWatcher() *BlockWatcher {
+	return &BlockWatcher{
+		watches: make(map[websockethub.ClientID]*blockWatch),
+	}
+}
+
+// Watch replaces the watched blocks of the client. An empty list stops watching.
+// An error is returned if the blocks would exceed the amount of blocks that can be watched by all clients.
+func (w *BlockWatcher) Watch(clientID websockethub.ClientID, blockIDs iotago.BlockIDs, send func(status *BlockStatus)) error {
+	w.Lock()
+	defer w.Unlock()
+
+	count := w.count
+	if previous, exists := w.watches[clientID]; exists {
+		count -= len(previous.blocks)
+	}
+
+	if len(blockIDs) == 0 {
+		delete(w.watches, clientID)
+		w.count = count
+
+		return nil
+	}
+
+	if count+len(blockIDs) > BlockWatcherMaxBlocks {
+		return errors.Errorf("too many watched blocks, max. %d", BlockWatcherMaxBlocks)
+	}
+
+	watch := &blockWatch{
+		send:   send,
+		blocks: make(map[iotago.BlockID]*BlockStatus, len(blockIDs)),
+	}
+	for _, blockID := range blockIDs {
+		watch.blocks[blockID] = nil
+	}
+	w.watches[clientID] = watch
+	w.count = count + len(watch.blocks)
+
+	return nil
+}
+
+// Unwatch stops watching all blocks of the client.
+func (w *BlockWatcher) Unwatch(clientID websockethub.ClientID) {
+	w.Lock()
+	defer w.Unlock()
+
+	if watch, exists := w.watches[clientID]; exists {
+		w.count -= len(watch.blocks)
+		delete(w.watches, clientID)
+	}
+}
+
+// BlockIDs returns the IDs of all watched blocks.
+func (w *BlockWatcher) BlockIDs() iotago.BlockIDs {
+	w.RLock()
+	defer w.RUnlock()
+
+	unique := make(map[iotago.BlockID]struct{})
+	for _, watch := range w.watches {
+		for blockID := range watch.blocks {
+			unique[blockID] = struct{}{}
+		}
+	}
+
+	blockIDs := make(iotago.BlockIDs, 0, len(unique))
+	for blockID := range unique {
+		blockIDs = append(blockIDs, blockID)
+	}
+
+	return blockIDs
+}
+
+// Update applies the metadata of a block and returns the status changes that need to be sent to the clients.
+func (w *BlockWatcher) Update(blockMeta *BlockMetadata) []*blockStatusUpdate {
+	w.Lock()
+	defer w.Unlock()
+
+	var updates []*blockStatusUpdate
+	for _, watch := range w.watches {
+		previous, watched := watch.blocks[blockMeta.BlockID]
+		if !watched {
+			continue
+		}
+
+		status := blockStatusFromBlockMetadata(blockMeta)
+		if previous != nil && *previous == *status {
+			continue
+		}
+		watch.blocks[blockMeta.BlockID] = status
+		updates = append(updates, &blockStatusUpdate{send: watch.send, status: status})
+
+		if status.IsReferenced {
+			// the status of referenced blocks doesn't change anymore
+			delete(watch.blocks, blockMeta.BlockID)
+			w.count--
+		}
+	}
+
+	return updates
+}
+
+// updateWatchedBlock applies the metadata of a watched block and sends the status changes to the clients.
+func (d *Dashboard) updateWatchedBlock(blockMeta *BlockMetadata) {
+	for _, update := range d.blockWatcher.Update(blockMeta) {
+		update.send(update.status)
+	}
+}
+
+// refreshWatchedBlocks queries the current metadata of the given blocks and sends the status changes to the clients.
+func (d *Dashboard) refreshWatchedBlocks(ctx context.Context, blockIDs iotago.BlockIDs) {
+	for _, blockID := range blockIDs {
+		ctxNode, ctxNodeCancel := context.WithTimeout(ctx, nodeTimeout)
+		metadata, err := d.nodeBridge.BlockMetadata(ctxNode, blockID)
+		ctxNodeCancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			// the block may not be known to the node yet
+			d.LogDebugf("failed to get metadata of watched block %s: %s", blockID.ToHex(), err)
+
+			continue
+		}
+
+		d.updateWatchedBlock(blockMetadataFromINXBlockMetadata(metadata))
+	}
+}
+
+// watchBlocks replaces the watched blocks of the client and sends their current status.
+func (d *Dashboard) watchBlocks(client *websockethub.Client, blockIDs iotago.BlockIDs) error {
+	if err := d.blockWatcher.Watch(client.ID(), blockIDs, func(status *BlockStatus) {
+		ctxMsg, ctxMsgCancel := context.WithTimeout(client.Context(), d.websocketWriteTimeout)
+		defer ctxMsgCancel()
+
+		_ = client.Send(ctxMsg, &Msg{Type: MsgTypeBlockStatus, Data: status}, true)
+	}); err != nil {
+		return err
+	}
+
+	go d.refreshWatchedBlocks(client.Context(), blockIDs)
+
+	return nil
+}
+
+func (d *Dashboard) runBlockWatcherFeed() {
+	if err := d.daemon.BackgroundWorker("Dashboard[BlockWatcher]", func(ctx context.Context) {
+		onBlockSolid := func(metadata *inx.BlockMetadata) {
+			d.updateWatchedBlock(blockMetadataFromINXBlockMetadata(metadata))
+		}
+
+		// the referenced state and the promotion/reattachment hints only change with new milestones.
+		// the blocks are refreshed in this worker to not block the event handlers of the node bridge,
+		// milestones that are confirmed during a refresh trigger a single follow-up refresh.
+		refreshSignal := make(chan struct{}, 1)
+		onConfirmedMilestoneChanged := func(_ *nodebridge.Milestone) {
+			select {
+			case refreshSignal <- struct{}{}:
+			default:
+			}
+		}
+
+		unhook := lo.Batch(
+			d.tangleListener.Events.BlockSolid.Hook(onBlockSolid).Unhook,
+			d.nodeBridge.Events.ConfirmedMilestoneChanged.Hook(onConfirmedMilestoneChanged).Unhook,
+		)
+		defer unhook()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-refreshSignal:
+				d.refreshWatchedBlocks(ctx, d.blockWatcher.BlockIDs())
+			}
+		}
+	}, daemon.PriorityStopDashboard); err != nil {
+		d.LogPanicf("failed to start worker: %s", err)
+	}
+}
@@ -0,0 +1,144 @@
+package dashboard
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/iotaledger/hive.go/web/websockethub"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+func testBlockID(index int) iotago.BlockID {
+	var blockID iotago.BlockID
+	binary.LittleEndian.PutUint32(blockID[:], uint32(index))
+
+	return blockID
+}
+
+func testBlockIDs(from int, count int) iotago.BlockIDs {
+	blockIDs := make(iotago.BlockIDs, count)
+	for i := range blockIDs {
+		blockIDs[i] = testBlockID(from + i)
+	}
+
+	return blockIDs
+}
+
+func TestBlockWatcherUpdate(t *testing.T) {
+	solid := func(index int) *BlockMetadata {
+		return &BlockMetadata{BlockID: testBlockID(index), IsSolid: true}
+	}
+	referenced := func(index int) *BlockMetadata {
+		return &BlockMetadata{BlockID: testBlockID(index), IsSolid: true, IsReferenced: true, ReferencedByMilestoneIndex: 10}
+	}
+
+	tests := []struct {
+		name    string
+		watches map[websockethub.ClientID]iotago.BlockIDs
+		updates []*BlockMetadata
+		// wantSent is the amount of status changes sent to each client.
+		wantSent  map[websockethub.ClientID]int
+		wantCount int
+	}{
+		{
+			name:      "unwatched block",
+			watches:   map[websockethub.ClientID]iotago.BlockIDs{1: testBlockIDs(0, 2)},
+			updates:   []*BlockMetadata{solid(5)},
+			wantSent:  map[websockethub.ClientID]int{1: 0},
+			wantCount: 2,
+		},
+		{
+			name:      "unchanged status is sent once",
+			watches:   map[websockethub.ClientID]iotago.BlockIDs{1: testBlockIDs(0, 2)},
+			updates:   []*BlockMetadata{solid(0), solid(0)},
+			wantSent:  map[websockethub.ClientID]int{1: 1},
+			wantCount: 2,
+		},
+		{
+			name:      "referenced blocks are no longer watched",
+			watches:   map[websockethub.ClientID]iotago.BlockIDs{1: testBlockIDs(0, 2)},
+			updates:   []*BlockMetadata{solid(0), referenced(0), referenced(0)},
+			wantSent:  map[websockethub.ClientID]int{1: 2},
+			wantCount: 1,
+		},
+		{
+			name: "block watched by multiple clients",
+			watches: map[websockethub.ClientID]iotago.BlockIDs{
+				1: testBlockIDs(0, 2),
+				2: testBlockIDs(1, 2),
+			},
+			updates:   []*BlockMetadata{referenced(1), solid(2)},
+			wantSent:  map[websockethub.ClientID]int{1: 1, 2: 2},
+			wantCount: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			watcher := NewBlockWatcher()
+
+			sent := make(map[websockethub.ClientID]int)
+			for clientID, blockIDs := range tt.watches {
+				clientID := clientID
+				if err := watcher.Watch(clientID, blockIDs, func(_ *BlockStatus) {
+					sent[clientID]++
+				}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			for _, blockMeta := range tt.updates {
+				for _, update := range watcher.Update(blockMeta) {
+					update.send(update.status)
+				}
+			}
+
+			for clientID, want := range tt.wantSent {
+				if sent[clientID] != want {
+					t.Errorf("client %d: expected %d status changes, got %d", clientID, want, sent[clientID])
+				}
+			}
+			if watcher.count != tt.wantCount {
+				t.Errorf("expected %d watched blocks, got %d", tt.wantCount, watcher.count)
+			}
+		})
+	}
+}
+
+func TestBlockWatcherWatchLimit(t *testing.T) {
+	watcher := NewBlockWatcher()
+	send := func(_ *BlockStatus) {}
+
+	clients := BlockWatcherMaxBlocks / BlockWatcherMaxBlocksPerClient
+	for i := 0; i < clients; i++ {
+		if err := watcher.Watch(websockethub.ClientID(i), testBlockIDs(i*BlockWatcherMaxBlocksPerClient, BlockWatcherMaxBlocksPerClient), send); err != nil {
+			t.Fatalf("client %d: %s", i, err)
+		}
+	}
+
+	if err := watcher.Watch(websockethub.ClientID(clients), testBlockIDs(0, 1), send); err == nil {
+		t.Fatal("expected an error above the maximum amount of watched blocks")
+	}
+
+	// replacing the blocks of a client doesn't count its previous blocks
+	if err := watcher.Watch(0, testBlockIDs(0, BlockWatcherMaxBlocksPerClient), send); err != nil {
+		t.Fatalf("expected the blocks of a client to be replaceable, got %s", err)
+	}
+
+	watcher.Unwatch(0)
+	if err := watcher.Watch(websockethub.ClientID(clients), testBlockIDs(0, 1), send); err != nil {
+		t.Fatalf("expected room for a new client after unwatching, got %s", err)
+	}
+
+	// an empty list stops watching
+	if err := watcher.Watch(1, nil, send); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := BlockWatcherMaxBlocks - 2*BlockWatcherMaxBlocksPerClient + 1; watcher.count != want {
+		t.Errorf("expected %d watched blocks, got %d", want, watcher.count)
+	}
+	if blockIDs := watcher.BlockIDs(); len(blockIDs) != watcher.count {
+		t.Errorf("expected %d unique watched blocks, got %d", watcher.count, len(blockIDs))
+	}
+}
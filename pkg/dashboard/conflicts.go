@@ -0,0 +1,260 @@
+package dashboard
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	"github.com/iotaledger/inx-dashboard/pkg/common"
+	"github.com/iotaledger/inx-dashboard/pkg/daemon"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	// ConflictLogSize is the maximum amount of conflicting blocks kept in the conflict log.
+	ConflictLogSize = 1000
+	// ConflictInitValues is the amount of conflicting blocks sent to new websocket clients.
+	ConflictInitValues = 50
+
+	// conflictsQueueSize is the amount of milestones whose conflicting blocks can be queued for recording.
+	conflictsQueueSize = 100
+
+	// QueryParameterBlockID is used to filter the conflicts by block ID.
+	QueryParameterBlockID = "blockId"
+	// QueryParameterTransactionID is used to filter the conflicts by transaction ID.
+	QueryParameterTransactionID = "transactionId"
+	// QueryParameterMilestoneIndex is used to filter the conflicts by the index of the referencing milestone.
+	QueryParameterMilestoneIndex = "milestoneIndex"
+	// QueryParameterReason is used to filter the conflicts by the conflict reason code.
+	QueryParameterReason = "reason"
+)
+
+// conflictReasonDescriptions explains the conflict reasons of the white flag confirmation.
+var conflictReasonDescriptions = map[inx.BlockMetadata_ConflictReason]string{
+	inx.BlockMetadata_CONFLICT_REASON_NONE:                                  "the block has no conflict",
+	inx.BlockMetadata_CONFLICT_REASON_INPUT_ALREADY_SPENT:                   "the referenced UTXO was already spent",
+	inx.BlockMetadata_CONFLICT_REASON_INPUT_ALREADY_SPENT_IN_THIS_MILESTONE: "the referenced UTXO was already spent while confirming this milestone",
+	inx.BlockMetadata_CONFLICT_REASON_INPUT_NOT_FOUND:                       "the referenced UTXO cannot be found",
+	inx.BlockMetadata_CONFLICT_REASON_INPUT_OUTPUT_SUM_MISMATCH:             "the sum of the inputs and output base token amount does not match",
+	inx.BlockMetadata_CONFLICT_REASON_INVALID_SIGNATURE:                     "the unlock block signature is invalid",
+	inx.BlockMetadata_CONFLICT_REASON_TIMELOCK_NOT_EXPIRED:                  "the configured timelock is not yet expired",
+	inx.BlockMetadata_CONFLICT_REASON_INVALID_NATIVE_TOKENS:                 "the given native tokens are invalid",
+	inx.BlockMetadata_CONFLICT_REASON_RETURN_AMOUNT_NOT_FULFILLED:           "the return amount in a transaction is not fulfilled by the output side",
+	inx.BlockMetadata_CONFLICT_REASON_INVALID_INPUT_UNLOCK:                  "an input unlock was invalid",
+	inx.BlockMetadata_CONFLICT_REASON_INVALID_INPUTS_COMMITMENT:             "the inputs commitment is invalid",
+	inx.BlockMetadata_CONFLICT_REASON_INVALID_SENDER:                        "an output contains a sender with an ident (address) which is not unlocked",
+	inx.BlockMetadata_CONFLICT_REASON_INVALID_CHAIN_STATE_TRANSITION:        "the chain state transition is invalid",
+	inx.BlockMetadata_CONFLICT_REASON_SEMANTIC_VALIDATION_FAILED:            "the semantic validation failed",
+}
+
+// ConflictingBlock holds the information why a block referenced by a milestone was not included in the ledger.
+type ConflictingBlock struct {
+	BlockID       string `json:"blockId"`
+	TransactionID string `json:"transactionId,omitempty"`
+	// MilestoneIndex is the index of the milestone that referenced the block.
+	MilestoneIndex     uint32 `json:"milestoneIndex"`
+	MilestoneTimestamp uint32 `json:"milestoneTimestamp"`
+	// ConflictReason is the conflict reason code of the white flag confirmation.
+	ConflictReason            int32  `json:"conflictReason"`
+	ConflictReasonDescription string `json:"conflictReasonDescription"`
+}
+
+// ConflictFilter restricts the results of a conflict log search. Empty fields match all conflicts.
+type ConflictFilter struct {
+	BlockID        string
+	TransactionID  string
+	MilestoneIndex uint32
+	ConflictReason *int32
+}
+
+func (f *ConflictFilter) matches(conflict *ConflictingBlock) bool {
+	if f.BlockID != "" && conflict.BlockID != f.BlockID {
+		return false
+	}
+	if f.TransactionID != "" && conflict.TransactionID != f.TransactionID {
+		return false
+	}
+	if f.MilestoneIndex != 0 && conflict.MilestoneIndex != f.MilestoneIndex {
+		return false
+	}
+	if f.ConflictReason != nil && conflict.ConflictReason != *f.ConflictReason {
+		return false
+	}
+
+	return true
+}
+
+// ConflictLog keeps a bounded log of the conflicting blocks referenced by milestones.
+type ConflictLog struct {
+	sync.RWMutex
+
+	capacity  int
+	conflicts []*ConflictingBlock
+}
+
+func NewConflictLog(capacity int) *ConflictLog {
+	return &ConflictLog{
+		capacity:  capacity,
+		conflicts: make([]*ConflictingBlock, 0),
+	}
+}
+
+// Add adds a conflicting block to the log. The oldest entries are dropped if the capacity is reached.
+func (l *ConflictLog) Add(conflict *ConflictingBlock) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.conflicts = append(l.conflicts, conflict)
+	if len(l.conflicts) > l.capacity {
+		l.conflicts = l.conflicts[len(l.conflicts)-l.capacity:]
+	}
+}
+
+// Recent returns up to count of the most recent conflicting blocks, oldest first.
+func (l *ConflictLog) Recent(count int) []*ConflictingBlock {
+	l.RLock()
+	defer l.RUnlock()
+
+	start := 0
+	if count < len(l.conflicts) {
+		start = len(l.conflicts) - count
+	}
+
+	recent := make([]*ConflictingBlock, len(l.conflicts)-start)
+	copy(recent, l.conflicts[start:])
+
+	return recent
+}
+
+// Search returns all conflicting blocks matching the filter, newest first.
+func (l *ConflictLog) Search(filter *ConflictFilter) []*ConflictingBlock {
+	l.RLock()
+	defer l.RUnlock()
+
+	results := make([]*ConflictingBlock, 0)
+	for i := len(l.conflicts) - 1; i >= 0; i-- {
+		if filter.matches(l.conflicts[i]) {
+			results = append(results, l.conflicts[i])
+		}
+	}
+
+	return results
+}
+
+func conflictReasonDescription(reason inx.BlockMetadata_ConflictReason) string {
+	if description, exists := conflictReasonDescriptions[reason]; exists {
+		return description
+	}
+
+	return "unknown conflict reason"
+}
+
+// queuedConflicts holds the conflicting blocks of a milestone waiting to be recorded.
+type queuedConflicts struct {
+	ms          *nodebridge.Milestone
+	conflicting []*BlockMetadata
+}
+
+// queueConflicts queues the conflicting blocks of a milestone for recording.
+// Looking up the transactions of the blocks must not delay the milestone feeds,
+// the conflicts are dropped if the queue is full.
+func (d *Dashboard) queueConflicts(ms *nodebridge.Milestone, conflicting []*BlockMetadata) {
+	if len(conflicting) == 0 {
+		return
+	}
+
+	select {
+	case d.conflictsQueue <- &queuedConflicts{ms: ms, conflicting: conflicting}:
+	default:
+		d.LogWarnf("dropped %d conflicting blocks of milestone %d, queue is full", len(conflicting), ms.Milestone.Index)
+	}
+}
+
+func (d *Dashboard) runConflictsRecorder() {
+	if err := d.daemon.BackgroundWorker("Dashboard[Conflicts]", func(ctx context.Context) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case queued := <-d.conflictsQueue:
+				d.recordConflicts(ctx, queued.ms, queued.conflicting)
+			}
+		}
+	}, daemon.PriorityStopDashboard); err != nil {
+		d.LogPanicf("failed to start worker: %s", err)
+	}
+}
+
+// recordConflicts adds the conflicting blocks of a confirmed milestone to the conflict log
+// and broadcasts them if clients subscribed to the conflicts.
+func (d *Dashboard) recordConflicts(ctx context.Context, ms *nodebridge.Milestone, conflicting []*BlockMetadata) {
+	for _, blockMeta := range conflicting {
+		conflict := &ConflictingBlock{
+			BlockID:                   blockMeta.BlockID.ToHex(),
+			MilestoneIndex:            ms.Milestone.Index,
+			MilestoneTimestamp:        ms.Milestone.Timestamp,
+			ConflictReason:            int32(blockMeta.ConflictReason),
+			ConflictReasonDescription: conflictReasonDescription(blockMeta.ConflictReason),
+		}
+
+		if block, err := d.getBlock(ctx, blockMeta.BlockID); err != nil {
+			d.LogWarnf("failed to get conflicting block %s: %s", blockMeta.BlockID.ToHex(), err)
+		} else if transaction, ok := block.Payload.(*iotago.Transaction); ok {
+			if transactionID, err := transaction.ID(); err == nil {
+				conflict.TransactionID = transactionID.ToHex()
+			}
+		}
+
+		d.conflictLog.Add(conflict)
+
+		if !d.subscriptionManager.TopicHasSubscribers(MsgTypeConflict) {
+			continue
+		}
+
+		ctxMsg, ctxMsgCancel := context.WithTimeout(ctx, d.websocketWriteTimeout)
+		_ = d.hub.BroadcastMsg(ctxMsg, &Msg{Type: MsgTypeConflict, Data: conflict})
+		ctxMsgCancel()
+	}
+}
+
+func parseConflictFilter(c echo.Context) (*ConflictFilter, error) {
+	filter := &ConflictFilter{
+		BlockID:       strings.ToLower(c.QueryParam(QueryParameterBlockID)),
+		TransactionID: strings.ToLower(c.QueryParam(QueryParameterTransactionID)),
+	}
+
+	if milestoneIndex := c.QueryParam(QueryParameterMilestoneIndex); milestoneIndex != "" {
+		index, err := strconv.ParseUint(milestoneIndex, 10, 32)
+		if err != nil {
+			return nil, errors.WithMessagef(common.ErrInvalidParameter, "invalid milestone index: %s, error: %s", milestoneIndex, err)
+		}
+		filter.MilestoneIndex = uint32(index)
+	}
+
+	if reason := c.QueryParam(QueryParameterReason); reason != "" {
+		code, err := strconv.ParseInt(reason, 10, 32)
+		if err != nil {
+			return nil, errors.WithMessagef(common.ErrInvalidParameter, "invalid conflict reason: %s, error: %s", reason, err)
+		}
+		conflictReason := int32(code)
+		filter.ConflictReason = &conflictReason
+	}
+
+	return filter, nil
+}
+
+func (d *Dashboard) conflictsRoute(c echo.Context) error {
+	filter, err := parseConflictFilter(c)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, d.conflictLog.Search(filter))
+}
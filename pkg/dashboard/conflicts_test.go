@@ -0,0 +1,132 @@
+package dashboard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+func TestConflictLogSearch(t *testing.T) {
+	log := NewConflictLog(3)
+	for _, conflict := range []*ConflictingBlock{
+		// dropped, the capacity is exceeded
+		{BlockID: "0x00", TransactionID: "0xa0", MilestoneIndex: 9, ConflictReason: 1},
+		{BlockID: "0x01", TransactionID: "0xa1", MilestoneIndex: 10, ConflictReason: 1},
+		{BlockID: "0x02", MilestoneIndex: 10, ConflictReason: 2},
+		{BlockID: "0x03", TransactionID: "0xa1", MilestoneIndex: 11, ConflictReason: 1},
+	} {
+		log.Add(conflict)
+	}
+
+	reason := func(reason int32) *int32 {
+		return &reason
+	}
+
+	tests := []struct {
+		name   string
+		filter *ConflictFilter
+		want   []string
+	}{
+		{"empty filter", &ConflictFilter{}, []string{"0x03", "0x02", "0x01"}},
+		{"block ID", &ConflictFilter{BlockID: "0x02"}, []string{"0x02"}},
+		{"dropped block ID", &ConflictFilter{BlockID: "0x00"}, []string{}},
+		{"transaction ID", &ConflictFilter{TransactionID: "0xa1"}, []string{"0x03", "0x01"}},
+		{"milestone index", &ConflictFilter{MilestoneIndex: 10}, []string{"0x02", "0x01"}},
+		{"conflict reason", &ConflictFilter{ConflictReason: reason(2)}, []string{"0x02"}},
+		{"conflict reason none", &ConflictFilter{ConflictReason: reason(0)}, []string{}},
+		{"combined", &ConflictFilter{TransactionID: "0xa1", MilestoneIndex: 11, ConflictReason: reason(1)}, []string{"0x03"}},
+		{"combined without match", &ConflictFilter{BlockID: "0x01", MilestoneIndex: 11}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := log.Search(tt.filter)
+
+			if len(results) != len(tt.want) {
+				t.Fatalf("expected %d results, got %d", len(tt.want), len(results))
+			}
+			for i, result := range results {
+				if result.BlockID != tt.want[i] {
+					t.Errorf("result %d: expected block %s, got %s", i, tt.want[i], result.BlockID)
+				}
+			}
+		})
+	}
+}
+
+func TestConflictLogRecent(t *testing.T) {
+	log := NewConflictLog(3)
+	for _, blockID := range []string{"0x00", "0x01", "0x02", "0x03"} {
+		log.Add(&ConflictingBlock{BlockID: blockID})
+	}
+
+	tests := []struct {
+		count int
+		want  []string
+	}{
+		{0, []string{}},
+		{2, []string{"0x02", "0x03"}},
+		{5, []string{"0x01", "0x02", "0x03"}},
+	}
+
+	for _, tt := range tests {
+		recent := log.Recent(tt.count)
+
+		if len(recent) != len(tt.want) {
+			t.Fatalf("count %d: expected %d conflicts, got %d", tt.count, len(tt.want), len(recent))
+		}
+		for i, conflict := range recent {
+			if conflict.BlockID != tt.want[i] {
+				t.Errorf("count %d: expected block %s at %d, got %s", tt.count, tt.want[i], i, conflict.BlockID)
+			}
+		}
+	}
+}
+
+func TestConflictsAreRecordedWithoutSubscribers(t *testing.T) {
+	included, conflicting := testBlockID(1), testBlockID(2)
+
+	d := New(nil, nil, newTestNodeBridge(t, &testINXServer{
+		cones: map[uint32][]*inx.BlockMetadata{
+			10: {
+				testConeBlock(included, 10, inx.BlockMetadata_LEDGER_INCLUSION_STATE_INCLUDED, inx.BlockMetadata_CONFLICT_REASON_NONE),
+				testConeBlock(conflicting, 10, inx.BlockMetadata_LEDGER_INCLUSION_STATE_CONFLICTING, inx.BlockMetadata_CONFLICT_REASON_INPUT_ALREADY_SPENT),
+			},
+		},
+	}), nil)
+	d.conflictsQueue = make(chan *queuedConflicts, conflictsQueueSize)
+
+	ms := &nodebridge.Milestone{Milestone: &iotago.Milestone{Index: 10, Timestamp: 1234}}
+	d.processConfirmedMilestone(context.Background(), ms, time.Now())
+
+	select {
+	case queued := <-d.conflictsQueue:
+		d.recordConflicts(context.Background(), queued.ms, queued.conflicting)
+	default:
+		t.Fatal("expected the conflicting blocks to be queued")
+	}
+
+	conflicts := d.conflictLog.Search(&ConflictFilter{})
+	if len(conflicts) != 1 {
+		t.Fatalf("expected 1 conflict, got %d", len(conflicts))
+	}
+
+	want := ConflictingBlock{
+		BlockID:                   conflicting.ToHex(),
+		MilestoneIndex:            10,
+		MilestoneTimestamp:        1234,
+		ConflictReason:            int32(inx.BlockMetadata_CONFLICT_REASON_INPUT_ALREADY_SPENT),
+		ConflictReasonDescription: conflictReasonDescription(inx.BlockMetadata_CONFLICT_REASON_INPUT_ALREADY_SPENT),
+	}
+	if *conflicts[0] != want {
+		t.Errorf("expected conflict %+v, got %+v", want, conflicts[0])
+	}
+
+	if recent := d.milestoneDetails.Recent(); len(recent) != 1 || recent[0].ConflictingBlocks != 1 {
+		t.Errorf("expected the details of the milestone to be recorded, got %+v", recent)
+	}
+}
@@ -7,9 +7,12 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/crypto/blake2b"
 
 	hivedaemon "github.com/iotaledger/hive.go/app/daemon"
@@ -21,16 +24,15 @@ import (
 	"github.com/iotaledger/hive.go/web/websockethub"
 	"github.com/iotaledger/inx-app/pkg/httpserver"
 	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	"github.com/iotaledger/inx-dashboard/pkg/alerting"
+	"github.com/iotaledger/inx-dashboard/pkg/cache"
 	"github.com/iotaledger/inx-dashboard/pkg/daemon"
 	"github.com/iotaledger/inx-dashboard/pkg/jwt"
+	"github.com/iotaledger/inx-dashboard/pkg/upstream"
+	"github.com/iotaledger/inx-dashboard/pkg/webhook"
 	"github.com/iotaledger/iota.go/v3/nodeclient"
 )
 
-const (
-	VisualizerInitValuesCount = 3000
-	VisualizerCapacity        = 3000
-)
-
 type Dashboard struct {
 	// the logger used to log events.
 	*logger.WrappedLogger
@@ -40,9 +42,11 @@ type Dashboard struct {
 	nodeBridge *nodebridge.NodeBridge
 	hub        *websockethub.Hub
 
+	appVersion               string
 	bindAddress              string
 	developerMode            bool
 	developerModeURL         string
+	trustedProxies           []string
 	authUsername             string
 	authPasswordHash         string
 	authPasswordSalt         string
@@ -55,17 +59,84 @@ type Dashboard struct {
 	authRateLimitMaxBurst    int
 	websocketWriteTimeout    time.Duration
 	debugLogRequests         bool
+	proxyTimeout             time.Duration
+	proxyMaxBodySize         int64
+	proxyCacheEnabled        bool
+	proxyCacheMaxEntries     int
+	proxyCacheMaxSize        int64
+	proxyRoutes              []string
+	visualizerCapacity       int
+	visualizerInitValues     int
+	visualizerAlwaysActive   bool
+	visualizerLingerPeriod   time.Duration
+
+	proxyRateLimitEnabled                         bool
+	proxyRateLimitPeriod                          time.Duration
+	proxyRateLimitMaxRequests                     int
+	proxyRateLimitMaxBurst                        int
+	proxyRateLimitIndexerMaxRequests              int
+	proxyRateLimitIndexerMaxBurst                 int
+	proxyRateLimitAuthenticatedMaxRequests        int
+	proxyRateLimitAuthenticatedMaxBurst           int
+	proxyRateLimitAuthenticatedIndexerMaxRequests int
+	proxyRateLimitAuthenticatedIndexerMaxBurst    int
+
+	proxyFailoverEndpoints           []string
+	proxyFailoverHealthCheckInterval time.Duration
+	proxyFailoverFailureThreshold    int
+	proxyFailoverOpenPeriod          time.Duration
+
+	alertsEnabled                  bool
+	alertsCheckInterval            time.Duration
+	alertsNodeUnsynced             bool
+	alertsMinPeers                 int
+	alertsMaxDatabaseGrowthPerHour int64
+	alertsMaxMilestoneLag          uint32
+
+	webhooksURLs         []string
+	webhooksSecret       string
+	webhooksMaxRetries   int
+	webhooksRetryBackoff time.Duration
+
+	tracingEnabled           bool
+	tracingCollectorEndpoint string
+	tracingSampleRatio       float64
 
 	basicAuth      *basicauth.BasicAuth
 	jwtAuth        *jwt.Auth
 	nodeClient     *nodeclient.Client
+	upstreams      *upstream.Pool
 	tangleListener *nodebridge.TangleListener
 	metricsClient  *MetricsClient
+	alertEngine    *alerting.Engine
+	webhooksSender *webhook.Sender
+	webhooksQueue  chan *queuedWebhook
+	conflictsQueue chan *queuedConflicts
+	responseCache  *cache.LRU
+	routeRegistry  *RouteRegistry
+	nodeFeatures   *NodeFeatures
+	// proxyRateLimiter is nil if rate limiting of the proxied routes is disabled.
+	proxyRateLimiter *proxyRateLimiter
+	// tracerProvider is nil if tracing is disabled.
+	tracerProvider *sdktrace.TracerProvider
+	tracer         trace.Tracer
 
 	visualizer          *Visualizer
+	milestoneDetails    *MilestoneDetailsTracker
+	peerHistory         *PeerHistory
+	nodeStateTracker    *nodeStateTracker
+	conflictLog         *ConflictLog
+	blockWatcher        *BlockWatcher
 	subscriptionManager *subscriptionmanager.SubscriptionManager[websockethub.ClientID, WebSocketMsgType]
 
-	cachedDatabaseSizeMetrics []*DatabaseSizesMetric
+	cachedDatabaseSizeMetricsLock sync.RWMutex
+	cachedDatabaseSizeMetrics     []*DatabaseSizesMetric
+}
+
+func WithAppVersion(appVersion string) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.appVersion = appVersion
+	}
 }
 
 func WithBindAddress(bindAddress string) options.Option[Dashboard] {
@@ -86,6 +157,12 @@ func WithDeveloperModeURL(developerModeURL string) options.Option[Dashboard] {
 	}
 }
 
+func WithTrustedProxies(trustedProxies []string) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.trustedProxies = trustedProxies
+	}
+}
+
 func WithAuthUsername(authUsername string) options.Option[Dashboard] {
 	return func(d *Dashboard) {
 		d.authUsername = authUsername
@@ -158,6 +235,228 @@ func WithDebugLogRequests(debugLogRequests bool) options.Option[Dashboard] {
 	}
 }
 
+func WithProxyTimeout(timeout time.Duration) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.proxyTimeout = timeout
+	}
+}
+
+func WithProxyMaxBodySize(maxBodySize int64) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.proxyMaxBodySize = maxBodySize
+	}
+}
+
+func WithProxyCacheEnabled(enabled bool) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.proxyCacheEnabled = enabled
+	}
+}
+
+func WithProxyCacheMaxEntries(maxEntries int) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.proxyCacheMaxEntries = maxEntries
+	}
+}
+
+func WithProxyCacheMaxSize(maxSize int64) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.proxyCacheMaxSize = maxSize
+	}
+}
+
+func WithProxyRoutes(routes []string) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.proxyRoutes = routes
+	}
+}
+
+func WithProxyRateLimitEnabled(enabled bool) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.proxyRateLimitEnabled = enabled
+	}
+}
+
+func WithProxyRateLimitPeriod(period time.Duration) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.proxyRateLimitPeriod = period
+	}
+}
+
+func WithProxyRateLimitMaxRequests(maxRequests int) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.proxyRateLimitMaxRequests = maxRequests
+	}
+}
+
+func WithProxyRateLimitMaxBurst(maxBurst int) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.proxyRateLimitMaxBurst = maxBurst
+	}
+}
+
+func WithProxyRateLimitIndexerMaxRequests(maxRequests int) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.proxyRateLimitIndexerMaxRequests = maxRequests
+	}
+}
+
+func WithProxyRateLimitIndexerMaxBurst(maxBurst int) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.proxyRateLimitIndexerMaxBurst = maxBurst
+	}
+}
+
+func WithProxyRateLimitAuthenticatedMaxRequests(maxRequests int) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.proxyRateLimitAuthenticatedMaxRequests = maxRequests
+	}
+}
+
+func WithProxyRateLimitAuthenticatedMaxBurst(maxBurst int) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.proxyRateLimitAuthenticatedMaxBurst = maxBurst
+	}
+}
+
+func WithProxyRateLimitAuthenticatedIndexerMaxRequests(maxRequests int) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.proxyRateLimitAuthenticatedIndexerMaxRequests = maxRequests
+	}
+}
+
+func WithProxyRateLimitAuthenticatedIndexerMaxBurst(maxBurst int) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.proxyRateLimitAuthenticatedIndexerMaxBurst = maxBurst
+	}
+}
+
+func WithProxyFailoverEndpoints(endpoints []string) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.proxyFailoverEndpoints = endpoints
+	}
+}
+
+func WithProxyFailoverHealthCheckInterval(healthCheckInterval time.Duration) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.proxyFailoverHealthCheckInterval = healthCheckInterval
+	}
+}
+
+func WithProxyFailoverFailureThreshold(failureThreshold int) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.proxyFailoverFailureThreshold = failureThreshold
+	}
+}
+
+func WithProxyFailoverOpenPeriod(openPeriod time.Duration) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.proxyFailoverOpenPeriod = openPeriod
+	}
+}
+
+func WithVisualizerCapacity(capacity int) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.visualizerCapacity = capacity
+	}
+}
+
+func WithVisualizerInitValues(initValues int) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.visualizerInitValues = initValues
+	}
+}
+
+func WithVisualizerAlwaysActive(alwaysActive bool) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.visualizerAlwaysActive = alwaysActive
+	}
+}
+
+func WithVisualizerLingerPeriod(lingerPeriod time.Duration) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.visualizerLingerPeriod = lingerPeriod
+	}
+}
+
+func WithAlertsEnabled(alertsEnabled bool) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.alertsEnabled = alertsEnabled
+	}
+}
+
+func WithAlertsCheckInterval(checkInterval time.Duration) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.alertsCheckInterval = checkInterval
+	}
+}
+
+func WithAlertsNodeUnsynced(nodeUnsynced bool) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.alertsNodeUnsynced = nodeUnsynced
+	}
+}
+
+func WithAlertsMinPeers(minPeers int) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.alertsMinPeers = minPeers
+	}
+}
+
+func WithAlertsMaxDatabaseGrowthPerHour(maxDatabaseGrowthPerHour int64) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.alertsMaxDatabaseGrowthPerHour = maxDatabaseGrowthPerHour
+	}
+}
+
+func WithAlertsMaxMilestoneLag(maxMilestoneLag uint32) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.alertsMaxMilestoneLag = maxMilestoneLag
+	}
+}
+
+func WithWebhooksURLs(urls []string) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.webhooksURLs = urls
+	}
+}
+
+func WithWebhooksSecret(secret string) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.webhooksSecret = secret
+	}
+}
+
+func WithWebhooksMaxRetries(maxRetries int) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.webhooksMaxRetries = maxRetries
+	}
+}
+
+func WithWebhooksRetryBackoff(retryBackoff time.Duration) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.webhooksRetryBackoff = retryBackoff
+	}
+}
+
+func WithTracingEnabled(enabled bool) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.tracingEnabled = enabled
+	}
+}
+
+func WithTracingCollectorEndpoint(collectorEndpoint string) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.tracingCollectorEndpoint = collectorEndpoint
+	}
+}
+
+func WithTracingSampleRatio(sampleRatio float64) options.Option[Dashboard] {
+	return func(d *Dashboard) {
+		d.tracingSampleRatio = sampleRatio
+	}
+}
+
 func New(
 	log *logger.Logger,
 	daemon hivedaemon.Daemon,
@@ -174,6 +473,7 @@ func New(
 		bindAddress:              "localhost:8081",
 		developerMode:            false,
 		developerModeURL:         "http://127.0.0.1:9090",
+		trustedProxies:           []string{},
 		authUsername:             "admin",
 		authPasswordHash:         "0000000000000000000000000000000000000000000000000000000000000000",
 		authPasswordSalt:         "0000000000000000000000000000000000000000000000000000000000000000",
@@ -186,23 +486,85 @@ func New(
 		authRateLimitMaxBurst:    30,
 		websocketWriteTimeout:    5 * time.Second,
 		debugLogRequests:         false,
-
-		visualizer:          NewVisualizer(log, nodeBridge, VisualizerCapacity),
+		proxyTimeout:             30 * time.Second,
+		proxyMaxBodySize:         10 * 1024 * 1024,
+		proxyCacheEnabled:        true,
+		proxyCacheMaxEntries:     10000,
+		proxyCacheMaxSize:        64 * 1024 * 1024,
+		proxyRoutes:              []string{},
+		visualizerCapacity:       3000,
+		visualizerInitValues:     3000,
+		visualizerAlwaysActive:   false,
+		visualizerLingerPeriod:   1 * time.Minute,
+
+		proxyRateLimitEnabled:                         true,
+		proxyRateLimitPeriod:                          1 * time.Minute,
+		proxyRateLimitMaxRequests:                     300,
+		proxyRateLimitMaxBurst:                        100,
+		proxyRateLimitIndexerMaxRequests:              60,
+		proxyRateLimitIndexerMaxBurst:                 20,
+		proxyRateLimitAuthenticatedMaxRequests:        3000,
+		proxyRateLimitAuthenticatedMaxBurst:           1000,
+		proxyRateLimitAuthenticatedIndexerMaxRequests: 600,
+		proxyRateLimitAuthenticatedIndexerMaxBurst:    200,
+
+		proxyFailoverEndpoints:           []string{},
+		proxyFailoverHealthCheckInterval: 10 * time.Second,
+		proxyFailoverFailureThreshold:    3,
+		proxyFailoverOpenPeriod:          30 * time.Second,
+
+		alertsEnabled:                  false,
+		alertsCheckInterval:            10 * time.Second,
+		alertsNodeUnsynced:             true,
+		alertsMinPeers:                 1,
+		alertsMaxDatabaseGrowthPerHour: 0,
+		alertsMaxMilestoneLag:          5,
+
+		webhooksURLs:         []string{},
+		webhooksSecret:       "",
+		webhooksMaxRetries:   3,
+		webhooksRetryBackoff: 1 * time.Second,
+
+		tracingEnabled:           false,
+		tracingCollectorEndpoint: "localhost:4318",
+		tracingSampleRatio:       1.0,
+
+		milestoneDetails:    NewMilestoneDetailsTracker(MilestoneDetailsCacheSize),
+		peerHistory:         NewPeerHistory(),
+		nodeStateTracker:    newNodeStateTracker(),
+		conflictLog:         NewConflictLog(ConflictLogSize),
+		blockWatcher:        NewBlockWatcher(),
 		subscriptionManager: subscriptionmanager.New[websockethub.ClientID, WebSocketMsgType](),
 	}, opts)
 
+	if d.proxyRateLimitEnabled {
+		d.proxyRateLimiter = d.newProxyRateLimiter()
+	}
+
+	if d.proxyCacheEnabled {
+		d.responseCache = cache.NewLRU(d.proxyCacheMaxEntries, d.proxyCacheMaxSize)
+	}
+
+	d.visualizer = NewVisualizer(log, nodeBridge, d.visualizerCapacity, d.visualizerLingerPeriod)
+
 	return d
 }
 
+// Visualizer returns the visualizer of the dashboard.
+func (d *Dashboard) Visualizer() *Visualizer {
+	return d.visualizer
+}
+
 func (d *Dashboard) checkVisualizerSubscriptions() {
 
-	active := false
+	active := d.visualizerAlwaysActive
 	for _, topic := range []WebSocketMsgType{
 		MsgTypeVisualizerVertex,
 		MsgTypeVisualizerSolidInfo,
 		MsgTypeVisualizerConfirmedInfo,
 		MsgTypeVisualizerMilestoneInfo,
-		MsgTypeVisualizerTipInfo} {
+		MsgTypeVisualizerTipInfo,
+		MsgTypeTangleAnalytics} {
 		if d.subscriptionManager.TopicHasSubscribers(topic) {
 			active = true
 
@@ -260,11 +622,30 @@ func (d *Dashboard) Init() {
 
 	d.nodeClient = d.nodeBridge.INXNodeClient()
 	d.tangleListener = nodebridge.NewTangleListener(d.nodeBridge)
-	d.metricsClient = NewMetricsClient(d.nodeClient)
+	d.initTracing()
+	d.upstreams = d.newUpstreamPool()
+	d.metricsClient = NewMetricsClient(d.upstreams, nodeTimeout)
+	d.nodeFeatures = NewNodeFeatures()
+
+	routeRegistry, err := NewRouteRegistry(d.proxyRoutes)
+	if err != nil {
+		d.LogErrorfAndExit("route registry initialization failed: %w", err)
+	}
+	d.routeRegistry = routeRegistry
+	d.alertEngine = alerting.NewEngine()
+	d.webhooksQueue = make(chan *queuedWebhook, webhooksQueueSize)
+	d.conflictsQueue = make(chan *queuedConflicts, conflictsQueueSize)
+	d.webhooksSender = webhook.NewSender(d.webhooksURLs,
+		webhook.WithSecret(d.webhooksSecret),
+		webhook.WithMaxRetries(d.webhooksMaxRetries),
+		webhook.WithRetryBackoff(d.webhooksRetryBackoff),
+	)
 }
 
 func (d *Dashboard) Run() {
 	e := httpserver.NewEcho(d.Logger(), nil, d.debugLogRequests)
+	e.IPExtractor = d.ipExtractor()
+	e.Pre(requestIDMiddleware())
 	d.setupRoutes(e)
 
 	if err := d.daemon.BackgroundWorker("Dashboard", func(ctx context.Context) {
@@ -279,6 +660,7 @@ func (d *Dashboard) Run() {
 			d.hub.Events().ClientDisconnected.Hook(func(event *websockethub.ClientConnectionEvent) {
 				d.LogDebugf("WebSocket client (ID: %d) connection closed", event.ID)
 				d.subscriptionManager.Disconnect(event.ID)
+				d.blockWatcher.Unwatch(event.ID)
 			}).Unhook,
 		)
 
@@ -340,12 +722,22 @@ func (d *Dashboard) Run() {
 		d.LogPanicf("failed to start worker: %s", err)
 	}
 
-	d.runNodeInfoFeed()
+	d.runNodeFeatureDiscovery()
+	d.runUpstreamHealthCheck()
+	d.runTracing()
+	d.runPublicNodeStatusFeed()
+	d.runConfirmedMilestoneMetricsFeed()
 	d.runNodeInfoExtendedFeed()
 	d.runGossipMetricsFeed()
 	d.runSyncStatusFeed()
 	d.runPeerMetricsFeed()
 	d.runMilestoneLiveFeed()
+	d.runMilestoneDetailsFeed()
+	d.runConflictsRecorder()
 	d.runVisualizerFeed()
+	d.runTangleAnalyticsFeed()
+	d.runBlockWatcherFeed()
 	d.runDatabaseSizeCollector()
+	d.runAlertsEngine()
+	d.runWebhooksDelivery()
 }
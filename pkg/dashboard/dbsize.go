@@ -16,6 +16,9 @@ func (d *Dashboard) currentDatabaseSize(ctx context.Context) *DatabaseSizesMetri
 		return nil
 	}
 
+	d.cachedDatabaseSizeMetricsLock.Lock()
+	defer d.cachedDatabaseSizeMetricsLock.Unlock()
+
 	d.cachedDatabaseSizeMetrics = append(d.cachedDatabaseSizeMetrics, newMetric)
 	if len(d.cachedDatabaseSizeMetrics) > 600 {
 		d.cachedDatabaseSizeMetrics = d.cachedDatabaseSizeMetrics[len(d.cachedDatabaseSizeMetrics)-600:]
@@ -24,6 +27,16 @@ func (d *Dashboard) currentDatabaseSize(ctx context.Context) *DatabaseSizesMetri
 	return newMetric
 }
 
+func (d *Dashboard) getCachedDatabaseSizeMetrics() []*DatabaseSizesMetric {
+	d.cachedDatabaseSizeMetricsLock.RLock()
+	defer d.cachedDatabaseSizeMetricsLock.RUnlock()
+
+	metrics := make([]*DatabaseSizesMetric, len(d.cachedDatabaseSizeMetrics))
+	copy(metrics, d.cachedDatabaseSizeMetrics)
+
+	return metrics
+}
+
 func (d *Dashboard) runDatabaseSizeCollector() {
 	if err := d.daemon.BackgroundWorker("Dashboard[DBSize]", func(ctx context.Context) {
 		// Gather first metric so we have a starting point
@@ -6,23 +6,29 @@ import (
 )
 
 type BlockMetadata struct {
-	BlockID        iotago.BlockID
-	Parents        iotago.BlockIDs
-	IsSolid        bool
-	IsReferenced   bool
-	IsConflicting  bool
-	ShouldPromote  bool
-	ShouldReattach bool
+	BlockID                    iotago.BlockID
+	Parents                    iotago.BlockIDs
+	IsSolid                    bool
+	IsReferenced               bool
+	ReferencedByMilestoneIndex uint32
+	IsIncluded                 bool
+	IsConflicting              bool
+	ConflictReason             inx.BlockMetadata_ConflictReason
+	ShouldPromote              bool
+	ShouldReattach             bool
 }
 
 func blockMetadataFromINXBlockMetadata(metadata *inx.BlockMetadata) *BlockMetadata {
 	return &BlockMetadata{
-		BlockID:        metadata.UnwrapBlockID(),
-		Parents:        metadata.UnwrapParents(),
-		IsSolid:        metadata.GetSolid(),
-		IsReferenced:   metadata.GetReferencedByMilestoneIndex() != 0,
-		IsConflicting:  metadata.GetConflictReason() != 0,
-		ShouldPromote:  metadata.GetShouldPromote(),
-		ShouldReattach: metadata.GetShouldReattach(),
+		BlockID:                    metadata.UnwrapBlockID(),
+		Parents:                    metadata.UnwrapParents(),
+		IsSolid:                    metadata.GetSolid(),
+		IsReferenced:               metadata.GetReferencedByMilestoneIndex() != 0,
+		ReferencedByMilestoneIndex: metadata.GetReferencedByMilestoneIndex(),
+		IsIncluded:                 metadata.GetLedgerInclusionState() == inx.BlockMetadata_LEDGER_INCLUSION_STATE_INCLUDED,
+		IsConflicting:              metadata.GetConflictReason() != 0,
+		ConflictReason:             metadata.GetConflictReason(),
+		ShouldPromote:              metadata.GetShouldPromote(),
+		ShouldReattach:             metadata.GetShouldReattach(),
 	}
 }
@@ -0,0 +1,152 @@
+package dashboard
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotaledger/hive.go/serializer/v2"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+// testProtocolParameters are the protocol parameters of the test node.
+var testProtocolParameters = &iotago.ProtocolParameters{
+	Version:     2,
+	NetworkName: "test",
+	Bech32HRP:   iotago.PrefixTestnet,
+	MinPoWScore: 0,
+	RentStructure: iotago.RentStructure{
+		VByteCost:    100,
+		VBFactorData: 1,
+		VBFactorKey:  10,
+	},
+	TokenSupply: 2_779_530_283_277_761,
+}
+
+// testINXServer is an INX node serving fixed blocks and milestone cones.
+type testINXServer struct {
+	inx.UnimplementedINXServer
+
+	blocks     map[iotago.BlockID]*iotago.Block
+	milestones map[uint32]*iotago.Milestone
+	cones      map[uint32][]*inx.BlockMetadata
+	// coneErrors are returned after the cone of the milestone was sent, the stream is truncated.
+	coneErrors map[uint32]error
+}
+
+func (s *testINXServer) ReadNodeConfiguration(_ context.Context, _ *inx.NoParams) (*inx.NodeConfiguration, error) {
+	return &inx.NodeConfiguration{}, nil
+}
+
+func (s *testINXServer) ReadNodeStatus(_ context.Context, _ *inx.NoParams) (*inx.NodeStatus, error) {
+	params, err := testProtocolParameters.Serialize(serializer.DeSeriModeNoValidation, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &inx.NodeStatus{
+		CurrentProtocolParameters: &inx.RawProtocolParameters{
+			ProtocolVersion: uint32(testProtocolParameters.Version),
+			Params:          params,
+		},
+	}, nil
+}
+
+func (s *testINXServer) ReadBlock(_ context.Context, blockID *inx.BlockId) (*inx.RawBlock, error) {
+	block, exists := s.blocks[blockID.Unwrap()]
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "block %s not found", blockID.Unwrap().ToHex())
+	}
+
+	return inx.WrapBlock(block)
+}
+
+func (s *testINXServer) ReadMilestone(_ context.Context, req *inx.MilestoneRequest) (*inx.Milestone, error) {
+	milestone, exists := s.milestones[req.GetMilestoneIndex()]
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "milestone %d not found", req.GetMilestoneIndex())
+	}
+
+	data, err := milestone.Serialize(serializer.DeSeriModeNoValidation, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &inx.Milestone{
+		MilestoneInfo: inx.NewMilestoneInfo(iotago.MilestoneID{}, milestone.Index, milestone.Timestamp),
+		Milestone:     &inx.RawMilestone{Data: data},
+	}, nil
+}
+
+// ListenToBlocks does not send any blocks, it keeps the stream open until the client cancels it.
+func (s *testINXServer) ListenToBlocks(_ *inx.NoParams, srv inx.INX_ListenToBlocksServer) error {
+	<-srv.Context().Done()
+
+	return nil
+}
+
+func (s *testINXServer) ReadMilestoneConeMetadata(req *inx.MilestoneRequest, srv inx.INX_ReadMilestoneConeMetadataServer) error {
+	for _, metadata := range s.cones[req.GetMilestoneIndex()] {
+		if err := srv.Send(metadata); err != nil {
+			return err
+		}
+	}
+
+	return s.coneErrors[req.GetMilestoneIndex()]
+}
+
+// newTestNodeBridge connects a node bridge to the given INX server.
+func newTestNodeBridge(t *testing.T, server *testINXServer) *nodebridge.NodeBridge {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	grpcServer := grpc.NewServer()
+	inx.RegisterINXServer(grpcServer, server)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	nodeBridge := nodebridge.NewNodeBridge(nil)
+	if err := nodeBridge.Connect(ctx, listener.Addr().String(), 1); err != nil {
+		t.Fatal(err)
+	}
+
+	return nodeBridge
+}
+
+// testConeBlock returns the metadata of a block referenced by the milestone with the given index.
+func testConeBlock(blockID iotago.BlockID, index uint32, inclusion inx.BlockMetadata_LedgerInclusionState, reason inx.BlockMetadata_ConflictReason) *inx.BlockMetadata {
+	return &inx.BlockMetadata{
+		BlockId:                    inx.NewBlockId(blockID),
+		Solid:                      true,
+		ReferencedByMilestoneIndex: index,
+		LedgerInclusionState:       inclusion,
+		ConflictReason:             reason,
+	}
+}
+
+// testBlock returns a block without payload that references the given parents.
+func testBlock(nonce uint64, parents ...iotago.BlockID) (iotago.BlockID, *iotago.Block) {
+	block := &iotago.Block{
+		ProtocolVersion: testProtocolParameters.Version,
+		Parents:         iotago.BlockIDs(parents).RemoveDupsAndSort(),
+		Nonce:           nonce,
+	}
+
+	return block.MustID(), block
+}
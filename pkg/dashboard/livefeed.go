@@ -2,6 +2,7 @@ package dashboard
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/iotaledger/hive.go/lo"
@@ -10,9 +11,65 @@ import (
 	"github.com/iotaledger/inx-dashboard/pkg/daemon"
 )
 
-func (d *Dashboard) runNodeInfoFeed() {
-	if err := d.daemon.BackgroundWorker("NodeInfo Feed", func(ctx context.Context) {
+func (d *Dashboard) runPublicNodeStatusFeed() {
+	if err := d.daemon.BackgroundWorker("PublicNodeStatus Feed", func(ctx context.Context) {
+		var lastStatusLock sync.Mutex
+		var lastStatus *PublicNodeStatus
+
+		// broadcastPublicNodeStatus sends the public node status to all clients if it changed
+		// or if force is set. The status is taken from the INX node status and needs no REST call.
+		broadcastPublicNodeStatus := func(force bool) {
+			publicNodeStatus := d.getPublicNodeStatus()
+			d.queueNodeEvents(d.nodeStateTracker.update(publicNodeStatus))
+
+			lastStatusLock.Lock()
+			changed := lastStatus == nil || *lastStatus != *publicNodeStatus
+			lastStatus = publicNodeStatus
+			lastStatusLock.Unlock()
+
+			if !changed && !force {
+				return
+			}
+
+			// skip if no client is connected
+			if d.hub.Clients() == 0 {
+				return
+			}
+
+			ctxMsg, ctxMsgCancel := context.WithTimeout(ctx, d.websocketWriteTimeout)
+			defer ctxMsgCancel()
+
+			_ = d.hub.BroadcastMsg(ctxMsg, &Msg{Type: MsgTypePublicNodeStatus, Data: publicNodeStatus})
+		}
+
+		onMilestoneChanged := func(_ *nodebridge.Milestone) {
+			broadcastPublicNodeStatus(true)
+		}
+
+		// register events
+		unhook := lo.Batch(
+			d.nodeBridge.Events.LatestMilestoneChanged.Hook(onMilestoneChanged).Unhook,
+			d.nodeBridge.Events.ConfirmedMilestoneChanged.Hook(onMilestoneChanged).Unhook,
+		)
+
+		// the health of the node may also change if no milestones arrive,
+		// so we check the cached INX node status for changes periodically.
 		ticker := timeutil.NewTicker(func() {
+			broadcastPublicNodeStatus(false)
+		}, 1*time.Second, ctx)
+		ticker.WaitForGracefulShutdown()
+
+		unhook()
+	}, daemon.PriorityStopDashboard); err != nil {
+		d.LogPanicf("failed to start worker: %s", err)
+	}
+}
+
+func (d *Dashboard) runConfirmedMilestoneMetricsFeed() {
+	if err := d.daemon.BackgroundWorker("ConfirmedMilestoneMetrics Feed", func(ctx context.Context) {
+		// the confirmed milestone metrics are not available via INX,
+		// but they only change if a new milestone was confirmed.
+		onConfirmedMilestoneChanged := func(_ *nodebridge.Milestone) {
 			// skip if no client is connected
 			if d.hub.Clients() == 0 {
 				return
@@ -25,15 +82,15 @@ func (d *Dashboard) runNodeInfoFeed() {
 				return
 			}
 
-			publicNodeStatus := getPublicNodeStatusByNodeInfo(nodeInfo, d.nodeBridge.IsNodeAlmostSynced())
-
 			ctxMsg, ctxMsgCancel := context.WithTimeout(ctx, d.websocketWriteTimeout)
 			defer ctxMsgCancel()
 
-			_ = d.hub.BroadcastMsg(ctxMsg, &Msg{Type: MsgTypePublicNodeStatus, Data: publicNodeStatus})
 			_ = d.hub.BroadcastMsg(ctxMsg, &Msg{Type: MsgTypeConfirmedMsMetrics, Data: nodeInfo.Metrics})
-		}, 1*time.Second, ctx)
-		ticker.WaitForGracefulShutdown()
+		}
+
+		unhook := d.nodeBridge.Events.ConfirmedMilestoneChanged.Hook(onConfirmedMilestoneChanged).Unhook
+		<-ctx.Done()
+		unhook()
 	}, daemon.PriorityStopDashboard); err != nil {
 		d.LogPanicf("failed to start worker: %s", err)
 	}
@@ -119,13 +176,12 @@ func (d *Dashboard) runMilestoneLiveFeed() {
 			ctxMsg, ctxMsgCancel := context.WithTimeout(ctx, d.websocketWriteTimeout)
 			defer ctxMsgCancel()
 
+			d.milestoneDetails.TrackLatestMilestone(ms.Milestone.Index)
+
 			_ = d.hub.BroadcastMsg(ctxMsg,
 				&Msg{
 					Type: MsgTypeMilestone,
-					Data: &Milestone{
-						MilestoneID: ms.MilestoneID.ToHex(),
-						Index:       ms.Milestone.Index,
-					},
+					Data: milestoneFromNodeBridgeMilestone(ms),
 				})
 		}
 
@@ -140,21 +196,37 @@ func (d *Dashboard) runMilestoneLiveFeed() {
 func (d *Dashboard) runPeerMetricsFeed() {
 
 	if err := d.daemon.BackgroundWorker("PeerMetrics Feed", func(ctx context.Context) {
+		var lastPoll time.Time
+
 		ticker := timeutil.NewTicker(func() {
-			// skip if no client is connected
-			if d.hub.Clients() == 0 {
+			// the peer infos are always fetched to keep track of the peer history,
+			// but less frequently if no client is connected.
+			if d.hub.Clients() == 0 && time.Since(lastPoll) < PeerHistoryInterval {
 				return
 			}
+			lastPoll = time.Now()
 
 			data, err := d.getPeerInfos(ctx)
 			if err != nil {
 				return
 			}
 
+			events := d.peerHistory.Update(data, time.Now())
+			d.queueNodeEvents(peerDisconnectedEvents(events))
+
+			// skip if no client is connected
+			if d.hub.Clients() == 0 {
+				return
+			}
+
 			ctxMsg, ctxMsgCancel := context.WithTimeout(ctx, d.websocketWriteTimeout)
 			defer ctxMsgCancel()
 
 			_ = d.hub.BroadcastMsg(ctxMsg, &Msg{Type: MsgTypePeerMetric, Data: data})
+
+			for _, event := range events {
+				_ = d.hub.BroadcastMsg(ctxMsg, &Msg{Type: MsgTypePeerHistory, Data: event})
+			}
 		}, 1*time.Second, ctx)
 		ticker.WaitForGracefulShutdown()
 	}, daemon.PriorityStopDashboard); err != nil {
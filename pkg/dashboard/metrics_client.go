@@ -3,44 +3,80 @@ package dashboard
 import (
 	"context"
 	"net/http"
+	"time"
 
-	"github.com/iotaledger/iota.go/v3/nodeclient"
+	"github.com/iotaledger/inx-dashboard/pkg/upstream"
 )
 
 // NewMetricsClient returns a new dashboard metrics node API instance.
-func NewMetricsClient(client *nodeclient.Client) *MetricsClient {
-	return &MetricsClient{Client: client}
+// Every node gets the given timeout to answer, a node that times out leaves enough time to ask the next one.
+func NewMetricsClient(upstreams *upstream.Pool, timeout time.Duration) *MetricsClient {
+	return &MetricsClient{
+		upstreams: upstreams,
+		timeout:   timeout,
+	}
 }
 
 // MetricsClient is an API wrapper over the dashboard metrics node API.
 type MetricsClient struct {
-	*nodeclient.Client
+	upstreams *upstream.Pool
+	timeout   time.Duration
+}
+
+// do executes a GET request against the endpoints until one answers and returns the name of the node that answered.
+func (client *MetricsClient) do(ctx context.Context, endpoints []*upstream.Endpoint, route string, resObj interface{}) (string, error) {
+	var err error
+	for _, endpoint := range endpoints {
+		ctxNode, ctxNodeCancel := context.WithTimeout(ctx, client.timeout)
+		//nolint:bodyclose // false positive, it is done in the client.Do method
+		_, err = endpoint.Client.Do(ctxNode, http.MethodGet, route, nil, resObj)
+		ctxNodeCancel()
+		if err == nil {
+			endpoint.ReportSuccess()
+
+			return endpoint.Name, nil
+		}
+
+		if !isUpstreamFailure(err) {
+			return "", err
+		}
+		endpoint.ReportFailure()
+
+		if ctx.Err() != nil {
+			// no time left to try the next node
+			return "", err
+		}
+	}
+
+	return "", err
 }
 
+// NodeInfoExtended is only answered by the INX connected node, the identity of other nodes must not be shown as its identity.
 func (client *MetricsClient) NodeInfoExtended(ctx context.Context) (*NodeInfoExtended, error) {
 	res := &NodeInfoExtended{}
-	//nolint:bodyclose // false positive, it is done in the client.Do method
-	if _, err := client.Do(ctx, http.MethodGet, RouteDashboardNodeInfoExtended, nil, res); err != nil {
+	upstreamName, err := client.do(ctx, []*upstream.Endpoint{client.upstreams.Primary()}, RouteDashboardNodeInfoExtended, res)
+	if err != nil {
 		return nil, err
 	}
+	res.Upstream = upstreamName
 
 	return res, nil
 }
 
+// DatabaseSizes is only answered by the INX connected node, the sizes of other nodes must not end up in its history.
 func (client *MetricsClient) DatabaseSizes(ctx context.Context) (*DatabaseSizesMetric, error) {
 	res := &DatabaseSizesMetric{}
-	//nolint:bodyclose // false positive, it is done in the client.Do method
-	if _, err := client.Do(ctx, http.MethodGet, RouteDashboardDatabaseSizes, nil, res); err != nil {
+	if _, err := client.do(ctx, []*upstream.Endpoint{client.upstreams.Primary()}, RouteDashboardDatabaseSizes, res); err != nil {
 		return nil, err
 	}
 
 	return res, nil
 }
 
+// GossipMetrics is only answered by the INX connected node, the gossip of other nodes is not related to its peers.
 func (client *MetricsClient) GossipMetrics(ctx context.Context) (*GossipMetrics, error) {
 	res := &GossipMetrics{}
-	//nolint:bodyclose // false positive, it is done in the client.Do method
-	if _, err := client.Do(ctx, http.MethodGet, RouteDashboardGossipMetrics, nil, res); err != nil {
+	if _, err := client.do(ctx, []*upstream.Endpoint{client.upstreams.Primary()}, RouteDashboardGossipMetrics, res); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,85 @@
+package dashboard
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/atomic"
+
+	"github.com/iotaledger/inx-dashboard/pkg/upstream"
+	"github.com/iotaledger/iota.go/v3/nodeclient"
+)
+
+func TestMetricsClientNodeInfoExtended(t *testing.T) {
+	// newNode returns a node that answers the node info with the given alias, or fails if the alias is empty.
+	newNode := func(alias string, requests *atomic.Int32) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Inc()
+
+			w.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if alias == "" {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = w.Write([]byte(`{"error":{"code":"503","message":"unavailable"}}`))
+
+				return
+			}
+			_, _ = w.Write([]byte(`{"nodeAlias":"` + alias + `"}`))
+		}))
+	}
+
+	tests := []struct {
+		name         string
+		primaryAlias string
+		wantErr      bool
+	}{
+		{
+			name:         "primary answers",
+			primaryAlias: "primary",
+		},
+		{
+			name:    "primary unavailable",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var primaryRequests, failoverRequests atomic.Int32
+
+			primary := newNode(tt.primaryAlias, &primaryRequests)
+			defer primary.Close()
+			failover := newNode("failover", &failoverRequests)
+			defer failover.Close()
+
+			pool := upstream.NewPool(UpstreamPrimaryName, nodeclient.New(primary.URL), 1, time.Minute, nil)
+			pool.Add("failover", nodeclient.New(failover.URL))
+
+			info, err := NewMetricsClient(pool, time.Second).NodeInfoExtended(context.Background())
+			if failoverRequests.Load() != 0 {
+				t.Errorf("expected the failover node not to be asked, got %d requests", failoverRequests.Load())
+			}
+			if primaryRequests.Load() != 1 {
+				t.Errorf("expected the primary node to be asked once, got %d requests", primaryRequests.Load())
+			}
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", info)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if info.NodeAlias != tt.primaryAlias || info.Upstream != UpstreamPrimaryName {
+				t.Errorf("expected the node info of the primary node, got %+v", info)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package dashboard
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	"github.com/iotaledger/inx-dashboard/pkg/daemon"
+	inx "github.com/iotaledger/inx/go"
+)
+
+const (
+	// MilestoneDetailsCacheSize is the amount of milestone details that are kept to initialize new clients.
+	MilestoneDetailsCacheSize = 20
+)
+
+func milestoneFromNodeBridgeMilestone(ms *nodebridge.Milestone) *Milestone {
+	return &Milestone{
+		MilestoneID: ms.MilestoneID.ToHex(),
+		Index:       ms.Milestone.Index,
+		Timestamp:   ms.Milestone.Timestamp,
+	}
+}
+
+// MilestoneDetailsTracker keeps track of the time milestones were seen
+// as the latest milestone and caches the details of recent confirmed milestones.
+type MilestoneDetailsTracker struct {
+	sync.RWMutex
+
+	capacity       int
+	latestSeenTime map[uint32]time.Time
+	recent         []*MilestoneDetails
+}
+
+func NewMilestoneDetailsTracker(capacity int) *MilestoneDetailsTracker {
+	return &MilestoneDetailsTracker{
+		capacity:       capacity,
+		latestSeenTime: make(map[uint32]time.Time),
+		recent:         make([]*MilestoneDetails, 0, capacity),
+	}
+}
+
+// TrackLatestMilestone stores the wall-clock time the milestone with the given index became the latest milestone.
+func (t *MilestoneDetailsTracker) TrackLatestMilestone(index uint32) {
+	t.Lock()
+	defer t.Unlock()
+
+	if _, exists := t.latestSeenTime[index]; !exists {
+		t.latestSeenTime[index] = time.Now()
+	}
+}
+
+// confirmationLatency returns the wall-clock delay between the milestone becoming
+// the latest milestone and the given confirmation time. Older entries are dropped.
+func (t *MilestoneDetailsTracker) confirmationLatency(index uint32, confirmationTime time.Time) time.Duration {
+	t.Lock()
+	defer t.Unlock()
+
+	var latency time.Duration
+	if seenTime, exists := t.latestSeenTime[index]; exists {
+		latency = confirmationTime.Sub(seenTime)
+	}
+
+	for msIndex := range t.latestSeenTime {
+		if msIndex <= index {
+			delete(t.latestSeenTime, msIndex)
+		}
+	}
+
+	return latency
+}
+
+// Add adds the details of a confirmed milestone to the cache.
+func (t *MilestoneDetailsTracker) Add(details *MilestoneDetails) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.recent = append(t.recent, details)
+	if len(t.recent) > t.capacity {
+		t.recent = t.recent[len(t.recent)-t.capacity:]
+	}
+}
+
+// Recent returns the details of the recently confirmed milestones.
+func (t *MilestoneDetailsTracker) Recent() []*MilestoneDetails {
+	t.RLock()
+	defer t.RUnlock()
+
+	recent := make([]*MilestoneDetails, len(t.recent))
+	copy(recent, t.recent)
+
+	return recent
+}
+
+// walkMilestoneCone passes the metadata of all blocks in the cone of the milestone to the consumer.
+// In contrast to the nodebridge, errors of the stream are returned, so partially walked cones are not mistaken for complete ones.
+func walkMilestoneCone(ctx context.Context, nodeBridge *nodebridge.NodeBridge, index uint32, consumer func(metadata *inx.BlockMetadata)) error {
+	stream, err := nodeBridge.Client().ReadMilestoneConeMetadata(ctx, &inx.MilestoneRequest{MilestoneIndex: index})
+	if err != nil {
+		return err
+	}
+
+	for {
+		metadata, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return errors.Wrapf(err, "walking the cone of milestone %d failed", index)
+		}
+
+		consumer(metadata)
+	}
+}
+
+// getMilestoneDetails walks the cone of the milestone and returns its details and the metadata of the conflicting blocks.
+func (d *Dashboard) getMilestoneDetails(ctx context.Context, ms *nodebridge.Milestone, confirmationTime time.Time) (*MilestoneDetails, []*BlockMetadata, error) {
+	ctxNode, ctxNodeCancel := context.WithTimeout(ctx, nodeTimeout)
+	defer ctxNodeCancel()
+
+	details := &MilestoneDetails{
+		MilestoneID:         ms.MilestoneID.ToHex(),
+		Index:               ms.Milestone.Index,
+		Timestamp:           ms.Milestone.Timestamp,
+		ConfirmationLatency: d.milestoneDetails.confirmationLatency(ms.Milestone.Index, confirmationTime).Milliseconds(),
+	}
+
+	conflicting := make([]*BlockMetadata, 0)
+	if err := walkMilestoneCone(ctxNode, d.nodeBridge, ms.Milestone.Index, func(metadata *inx.BlockMetadata) {
+		blockMeta := blockMetadataFromINXBlockMetadata(metadata)
+
+		details.ReferencedBlocks++
+		if blockMeta.IsIncluded {
+			details.IncludedBlocks++
+		}
+		if blockMeta.IsConflicting {
+			details.ConflictingBlocks++
+			conflicting = append(conflicting, blockMeta)
+		}
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	return details, conflicting, nil
+}
+
+// processConfirmedMilestone walks the cone of a confirmed milestone to record its details and conflicting blocks.
+// The cone is walked for every milestone, the conflict log has to be complete even if nobody has the dashboard open.
+func (d *Dashboard) processConfirmedMilestone(ctx context.Context, ms *nodebridge.Milestone, confirmationTime time.Time) {
+	details, conflicting, err := d.getMilestoneDetails(ctx, ms, confirmationTime)
+	if err != nil {
+		d.LogWarnf("failed to get milestone details for milestone %d: %s", ms.Milestone.Index, err)
+
+		return
+	}
+	d.milestoneDetails.Add(details)
+	d.queueConflicts(ms, conflicting)
+
+	if !d.subscriptionManager.TopicHasSubscribers(MsgTypeMilestoneDetails) {
+		return
+	}
+
+	ctxMsg, ctxMsgCancel := context.WithTimeout(ctx, d.websocketWriteTimeout)
+	defer ctxMsgCancel()
+
+	_ = d.hub.BroadcastMsg(ctxMsg, &Msg{Type: MsgTypeMilestoneDetails, Data: details})
+}
+
+func (d *Dashboard) runMilestoneDetailsFeed() {
+	if err := d.daemon.BackgroundWorker("MilestoneDetails Feed", func(ctx context.Context) {
+		onConfirmedMilestoneChanged := func(ms *nodebridge.Milestone) {
+			d.processConfirmedMilestone(ctx, ms, time.Now())
+		}
+
+		unhook := d.nodeBridge.Events.ConfirmedMilestoneChanged.Hook(onConfirmedMilestoneChanged).Unhook
+		<-ctx.Done()
+		unhook()
+	}, daemon.PriorityStopDashboard); err != nil {
+		d.LogPanicf("failed to start worker: %s", err)
+	}
+}
@@ -0,0 +1,160 @@
+package dashboard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+func TestMilestoneDetailsTracker(t *testing.T) {
+	tracker := NewMilestoneDetailsTracker(3)
+
+	tracker.TrackLatestMilestone(10)
+	seenTime := tracker.latestSeenTime[10]
+	// a milestone is only tracked the first time it is seen
+	tracker.TrackLatestMilestone(10)
+	if tracker.latestSeenTime[10] != seenTime {
+		t.Errorf("expected the first seen time to be kept")
+	}
+	tracker.latestSeenTime[9] = seenTime
+	tracker.latestSeenTime[11] = seenTime
+
+	if latency := tracker.confirmationLatency(10, seenTime.Add(3*time.Second)); latency != 3*time.Second {
+		t.Errorf("expected a latency of 3s, got %s", latency)
+	}
+	// the confirmed and older milestones are dropped
+	if _, exists := tracker.latestSeenTime[9]; exists {
+		t.Error("expected older milestones to be dropped")
+	}
+	if _, exists := tracker.latestSeenTime[11]; !exists {
+		t.Error("expected newer milestones to be kept")
+	}
+	if latency := tracker.confirmationLatency(10, seenTime.Add(time.Second)); latency != 0 {
+		t.Errorf("expected no latency for an untracked milestone, got %s", latency)
+	}
+
+	for index := uint32(1); index <= 4; index++ {
+		tracker.Add(&MilestoneDetails{Index: index})
+	}
+
+	recent := tracker.Recent()
+	if len(recent) != 3 || recent[0].Index != 2 || recent[2].Index != 4 {
+		t.Fatalf("expected the 3 most recent milestones, got %+v", recent)
+	}
+	// the returned slice is a copy
+	recent[0] = nil
+	if tracker.Recent()[0] == nil {
+		t.Error("expected Recent to return a copy")
+	}
+}
+
+func TestGetMilestoneDetails(t *testing.T) {
+	server := &testINXServer{
+		cones: map[uint32][]*inx.BlockMetadata{
+			10: {
+				testConeBlock(testBlockID(1), 10, inx.BlockMetadata_LEDGER_INCLUSION_STATE_INCLUDED, inx.BlockMetadata_CONFLICT_REASON_NONE),
+				testConeBlock(testBlockID(2), 10, inx.BlockMetadata_LEDGER_INCLUSION_STATE_NO_TRANSACTION, inx.BlockMetadata_CONFLICT_REASON_NONE),
+				testConeBlock(testBlockID(3), 10, inx.BlockMetadata_LEDGER_INCLUSION_STATE_INCLUDED, inx.BlockMetadata_CONFLICT_REASON_NONE),
+				testConeBlock(testBlockID(4), 10, inx.BlockMetadata_LEDGER_INCLUSION_STATE_CONFLICTING, inx.BlockMetadata_CONFLICT_REASON_INPUT_ALREADY_SPENT),
+				testConeBlock(testBlockID(5), 10, inx.BlockMetadata_LEDGER_INCLUSION_STATE_CONFLICTING, inx.BlockMetadata_CONFLICT_REASON_INVALID_SIGNATURE),
+			},
+			12: {
+				testConeBlock(testBlockID(6), 12, inx.BlockMetadata_LEDGER_INCLUSION_STATE_CONFLICTING, inx.BlockMetadata_CONFLICT_REASON_INPUT_NOT_FOUND),
+			},
+		},
+		coneErrors: map[uint32]error{
+			12: status.Error(codes.Unavailable, "stream broken"),
+		},
+	}
+
+	tests := []struct {
+		name            string
+		index           uint32
+		wantReferenced  int
+		wantIncluded    int
+		wantConflicting []inx.BlockMetadata_ConflictReason
+		wantErr         bool
+	}{
+		{
+			name:           "mixed cone",
+			index:          10,
+			wantReferenced: 5,
+			wantIncluded:   2,
+			wantConflicting: []inx.BlockMetadata_ConflictReason{
+				inx.BlockMetadata_CONFLICT_REASON_INPUT_ALREADY_SPENT,
+				inx.BlockMetadata_CONFLICT_REASON_INVALID_SIGNATURE,
+			},
+		},
+		{
+			name:            "empty cone",
+			index:           11,
+			wantConflicting: []inx.BlockMetadata_ConflictReason{},
+		},
+		{
+			name:    "truncated cone",
+			index:   12,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New(nil, nil, newTestNodeBridge(t, server), nil)
+			d.conflictsQueue = make(chan *queuedConflicts, conflictsQueueSize)
+
+			ms := &nodebridge.Milestone{
+				MilestoneID: iotago.MilestoneID{byte(tt.index)},
+				Milestone:   &iotago.Milestone{Index: tt.index, Timestamp: 1000 + tt.index},
+			}
+			d.milestoneDetails.TrackLatestMilestone(tt.index)
+			seenTime := d.milestoneDetails.latestSeenTime[tt.index]
+
+			details, conflicting, err := d.getMilestoneDetails(context.Background(), ms, seenTime.Add(2*time.Second))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+
+				// nothing is recorded for partially walked cones
+				d.processConfirmedMilestone(context.Background(), ms, time.Now())
+				if len(d.milestoneDetails.Recent()) != 0 || len(d.conflictsQueue) != 0 {
+					t.Error("expected nothing to be recorded")
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := MilestoneDetails{
+				MilestoneID:         ms.MilestoneID.ToHex(),
+				Index:               tt.index,
+				Timestamp:           1000 + tt.index,
+				ReferencedBlocks:    tt.wantReferenced,
+				IncludedBlocks:      tt.wantIncluded,
+				ConflictingBlocks:   len(tt.wantConflicting),
+				ConfirmationLatency: 2000,
+			}
+			if *details != want {
+				t.Errorf("expected %+v, got %+v", want, details)
+			}
+
+			if len(conflicting) != len(tt.wantConflicting) {
+				t.Fatalf("expected %d conflicting blocks, got %d", len(tt.wantConflicting), len(conflicting))
+			}
+			for i, blockMeta := range conflicting {
+				if !blockMeta.IsConflicting || blockMeta.ConflictReason != tt.wantConflicting[i] {
+					t.Errorf("conflicting block %d: expected reason %s, got %+v", i, tt.wantConflicting[i], blockMeta)
+				}
+			}
+		})
+	}
+}
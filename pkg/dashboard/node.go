@@ -4,6 +4,12 @@ import (
 	"context"
 	"time"
 
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotaledger/inx-dashboard/pkg/common"
+	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/iotaledger/iota.go/v3/nodeclient"
 )
 
@@ -11,11 +17,14 @@ const (
 	nodeTimeout = 5 * time.Second
 )
 
-func getPublicNodeStatusByNodeInfo(nodeInfo *nodeclient.InfoResponse, isAlmostSynced bool) *PublicNodeStatus {
+// getPublicNodeStatus derives the public node status from the node status received via INX.
+func (d *Dashboard) getPublicNodeStatus() *PublicNodeStatus {
+	nodeStatus := d.nodeBridge.NodeStatus()
+
 	return &PublicNodeStatus{
-		PruningIndex: nodeInfo.Status.PruningIndex,
-		IsHealthy:    nodeInfo.Status.IsHealthy,
-		IsSynced:     isAlmostSynced,
+		PruningIndex: nodeStatus.GetTanglePruningIndex(),
+		IsHealthy:    nodeStatus.GetIsHealthy(),
+		IsSynced:     nodeStatus.GetIsAlmostSynced(),
 	}
 }
 
@@ -26,11 +35,9 @@ func (d *Dashboard) getNodeInfo(ctx context.Context) (*nodeclient.InfoResponse,
 	return d.nodeClient.Info(ctxNode)
 }
 
+// getNodeInfoExtended queries the extended node info of the INX connected node.
 func (d *Dashboard) getNodeInfoExtended(ctx context.Context) (*NodeInfoExtended, error) {
-	ctxNode, ctxNodecancel := context.WithTimeout(ctx, nodeTimeout)
-	defer ctxNodecancel()
-
-	return d.metricsClient.NodeInfoExtended(ctxNode)
+	return d.metricsClient.NodeInfoExtended(ctx)
 }
 
 func (d *Dashboard) getPeerInfos(ctx context.Context) ([]*nodeclient.PeerResponse, error) {
@@ -48,32 +55,51 @@ func (d *Dashboard) getSyncStatus() *SyncStatus {
 }
 
 func (d *Dashboard) getGossipMetrics(ctx context.Context) (*GossipMetrics, error) {
-	ctxNode, ctxNodecancel := context.WithTimeout(ctx, nodeTimeout)
-	defer ctxNodecancel()
-
-	return d.metricsClient.GossipMetrics(ctxNode)
+	return d.metricsClient.GossipMetrics(ctx)
 }
 
 func (d *Dashboard) getDatabaseSizeMetric(ctx context.Context) (*DatabaseSizesMetric, error) {
-	ctxNode, ctxNodecancel := context.WithTimeout(ctx, nodeTimeout)
-	defer ctxNodecancel()
-
-	return d.metricsClient.DatabaseSizes(ctxNode)
+	return d.metricsClient.DatabaseSizes(ctx)
 }
 
 func (d *Dashboard) getLatestMilestoneIndex() uint32 {
 	return d.nodeBridge.LatestMilestoneIndex()
 }
 
-func (d *Dashboard) getMilestoneIDHex(ctx context.Context, index uint32) (string, error) {
+// isNotFoundError returns true if the node does not know the requested resource, either via INX or via the node API.
+func isNotFoundError(err error) bool {
+	return status.Code(err) == codes.NotFound || errors.Is(err, nodeclient.ErrHTTPNotFound)
+}
+
+// nodeAPIError maps the errors of the node API that are caused by the request to the corresponding HTTP errors,
+// so that they are not reported as internal server errors. All other errors are returned unchanged.
+func nodeAPIError(err error) error {
+	switch {
+	case isNotFoundError(err):
+		return errors.WithMessage(common.ErrNotFound, err.Error())
+	case errors.Is(err, nodeclient.ErrHTTPBadRequest):
+		return errors.WithMessage(common.ErrInvalidParameter, err.Error())
+	default:
+		return err
+	}
+}
+
+func (d *Dashboard) getBlock(ctx context.Context, blockID iotago.BlockID) (*iotago.Block, error) {
+	ctxNode, ctxNodecancel := context.WithTimeout(ctx, nodeTimeout)
+	defer ctxNodecancel()
+
+	return d.nodeBridge.Block(ctxNode, blockID)
+}
+
+func (d *Dashboard) getMilestone(ctx context.Context, index uint32) (*Milestone, error) {
 
 	ctxNode, ctxNodecancel := context.WithTimeout(ctx, nodeTimeout)
 	defer ctxNodecancel()
 
 	milestone, err := d.nodeBridge.Milestone(ctxNode, index)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return milestone.MilestoneID.ToHex(), nil
+	return milestoneFromNodeBridgeMilestone(milestone), nil
 }
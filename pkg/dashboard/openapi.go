@@ -0,0 +1,538 @@
+package dashboard
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/inx-dashboard/pkg/alerting"
+	"github.com/iotaledger/iota.go/v3/nodeclient"
+)
+
+const (
+	// OpenAPIVersion is the version of the OpenAPI specification the document follows.
+	OpenAPIVersion = "3.0.3"
+
+	openAPISecuritySchemeJWT = "jwt"
+)
+
+// OpenAPIDocument is an OpenAPI 3 document.
+// Only the parts of the specification used by the dashboard are modeled.
+type OpenAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       *OpenAPIInfo                            `json:"info"`
+	Paths      map[string]map[string]*OpenAPIOperation `json:"paths"`
+	Components *OpenAPIComponents                      `json:"components"`
+}
+
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type OpenAPIComponents struct {
+	Schemas         map[string]*OpenAPISchema         `json:"schemas"`
+	SecuritySchemes map[string]*OpenAPISecurityScheme `json:"securitySchemes"`
+}
+
+type OpenAPISecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+type OpenAPIOperation struct {
+	Summary     string                      `json:"summary,omitempty"`
+	Description string                      `json:"description,omitempty"`
+	Tags        []string                    `json:"tags,omitempty"`
+	Parameters  []*OpenAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*OpenAPIResponse `json:"responses"`
+	// Security is empty for public operations.
+	Security []map[string][]string `json:"security,omitempty"`
+}
+
+type OpenAPIParameter struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required"`
+	Schema      *OpenAPISchema `json:"schema"`
+}
+
+type OpenAPIRequestBody struct {
+	Required bool                         `json:"required"`
+	Content  map[string]*OpenAPIMediaType `json:"content"`
+}
+
+type OpenAPIResponse struct {
+	Description string                       `json:"description"`
+	Content     map[string]*OpenAPIMediaType `json:"content,omitempty"`
+}
+
+type OpenAPIMediaType struct {
+	Schema *OpenAPISchema `json:"schema"`
+}
+
+type OpenAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Description          string                    `json:"description,omitempty"`
+	Enum                 []any                     `json:"enum,omitempty"`
+	Properties           map[string]*OpenAPISchema `json:"properties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	Items                *OpenAPISchema            `json:"items,omitempty"`
+	AdditionalProperties *OpenAPISchema            `json:"additionalProperties,omitempty"`
+	OneOf                []*OpenAPISchema          `json:"oneOf,omitempty"`
+	Nullable             bool                      `json:"nullable,omitempty"`
+}
+
+// websocketTopic describes the messages the dashboard sends on a websocket topic.
+type websocketTopic struct {
+	Type WebSocketMsgType
+	Name string
+	// Data is a value of the type sent in the "data" field of the message.
+	Data any
+}
+
+// websocketTopics are all topics of the websocket.
+var websocketTopics = []*websocketTopic{
+	{Type: MsgTypeSyncStatus, Name: "SyncStatus", Data: &SyncStatus{}},
+	{Type: MsgTypePublicNodeStatus, Name: "PublicNodeStatus", Data: &PublicNodeStatus{}},
+	{Type: MsgTypeNodeInfoExtended, Name: "NodeInfoExtended", Data: &NodeInfoExtended{}},
+	{Type: MsgTypeGossipMetrics, Name: "GossipMetrics", Data: &GossipMetrics{}},
+	{Type: MsgTypeMilestone, Name: "Milestone", Data: &Milestone{}},
+	{Type: MsgTypePeerMetric, Name: "PeerMetric", Data: []*nodeclient.PeerResponse{}},
+	{Type: MsgTypeConfirmedMsMetrics, Name: "ConfirmedMsMetrics", Data: &nodeclient.InfoResMetrics{}},
+	{Type: MsgTypeVisualizerVertex, Name: "VisualizerVertex", Data: &VisualizerVertex{}},
+	{Type: MsgTypeVisualizerSolidInfo, Name: "VisualizerSolidInfo", Data: &VisualizerMetaInfo{}},
+	{Type: MsgTypeVisualizerConfirmedInfo, Name: "VisualizerConfirmedInfo", Data: &VisualizerConfirmationInfo{}},
+	{Type: MsgTypeVisualizerMilestoneInfo, Name: "VisualizerMilestoneInfo", Data: &VisualizerMetaInfo{}},
+	{Type: MsgTypeVisualizerTipInfo, Name: "VisualizerTipInfo", Data: &VisualizerTipInfo{}},
+	{Type: MsgTypeDatabaseSizeMetric, Name: "DatabaseSizeMetric", Data: []*DatabaseSizesMetric{}},
+	{Type: MsgTypeMilestoneDetails, Name: "MilestoneDetails", Data: &MilestoneDetails{}},
+	{Type: MsgTypeAlert, Name: "Alert", Data: &alerting.Alert{}},
+	{Type: MsgTypePeerHistory, Name: "PeerHistory", Data: &PeerConnectivityEvent{}},
+	{Type: MsgTypeTangleAnalytics, Name: "TangleAnalytics", Data: &TangleAnalytics{}},
+	{Type: MsgTypeVisualizerReplayVertex, Name: "VisualizerReplayVertex", Data: &VisualizerVertex{}},
+	{Type: MsgTypeVisualizerReplayConfirmedInfo, Name: "VisualizerReplayConfirmedInfo", Data: &VisualizerReplayConfirmationInfo{}},
+	{Type: MsgTypeVisualizerReplayStatus, Name: "VisualizerReplayStatus", Data: &VisualizerReplayStatus{}},
+	{Type: MsgTypeVisualizerHighlight, Name: "VisualizerHighlight", Data: &VisualizerHighlight{}},
+	{Type: MsgTypeConflict, Name: "Conflict", Data: &ConflictingBlock{}},
+	{Type: MsgTypeBlockStatus, Name: "BlockStatus", Data: &BlockStatus{}},
+}
+
+const websocketDescription = `The websocket sends JSON encoded messages of the form {"type": <topic>, "data": <payload>}.
+
+Clients send binary commands: the first byte is the command, the second byte is the topic, followed by the command specific payload.
+- 0 (register): subscribes to the topic. Protected topics need the JWT as payload.
+- 1 (unregister): unsubscribes from the topic.
+- 2 (filter): sets the 0x-prefixed hex encoded tag prefix filter of the visualizer vertices, an empty payload removes the filter.
+- 3 (replay): replays confirmed milestone cones, the payload is the little endian encoded start index, end index and blocks per second (uint32 each).
+- 4 (highlight): requests the past and future cone of the 0x-prefixed hex encoded block ID in the payload.
+- 5 (watch): replaces the watched blocks with the comma separated list of 0x-prefixed hex encoded block IDs in the payload.`
+
+var openAPIPathParameterRegex = regexp.MustCompile(`:(\w+)`)
+
+// openAPIPath converts an echo route to an OpenAPI path and returns the names of its path parameters.
+func openAPIPath(route string) (string, []string) {
+	var parameters []string
+	for _, match := range openAPIPathParameterRegex.FindAllStringSubmatch(route, -1) {
+		parameters = append(parameters, match[1])
+	}
+
+	return openAPIPathParameterRegex.ReplaceAllString(route, "{$1}"), parameters
+}
+
+// openAPISchemaGenerator derives schemas from Go types and collects the named ones as components.
+type openAPISchemaGenerator struct {
+	schemas map[string]*OpenAPISchema
+	names   map[reflect.Type]string
+}
+
+func newOpenAPISchemaGenerator() *openAPISchemaGenerator {
+	return &openAPISchemaGenerator{
+		schemas: make(map[string]*OpenAPISchema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+var (
+	typeTime          = reflect.TypeOf(time.Time{})
+	typeBigInt        = reflect.TypeOf(big.Int{})
+	typeRawMessage    = reflect.TypeOf(json.RawMessage{})
+	typeJSONMarshaler = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	typeTextMarshaler = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// componentName returns a unique name for the named type.
+func (g *openAPISchemaGenerator) componentName(t reflect.Type) string {
+	if name, exists := g.names[t]; exists {
+		return name
+	}
+
+	name := t.Name()
+	for _, other := range g.names {
+		if other == name {
+			// types of different packages can share the name
+			pkgPath := strings.Split(t.PkgPath(), "/")
+			name = pkgPath[len(pkgPath)-1] + name
+
+			break
+		}
+	}
+	g.names[t] = name
+
+	return name
+}
+
+// SchemaOf returns the schema of the JSON representation of the value.
+func (g *openAPISchemaGenerator) SchemaOf(value any) *OpenAPISchema {
+	if value == nil {
+		return &OpenAPISchema{}
+	}
+
+	return g.schemaOfType(reflect.TypeOf(value))
+}
+
+func (g *openAPISchemaGenerator) schemaOfType(t reflect.Type) *OpenAPISchema {
+	switch t {
+	case typeTime:
+		return &OpenAPISchema{Type: "string", Format: "date-time"}
+	case typeBigInt:
+		return &OpenAPISchema{Type: "integer"}
+	case typeRawMessage:
+		return &OpenAPISchema{}
+	}
+
+	if t.Kind() != reflect.Pointer && t.Kind() != reflect.Interface {
+		if reflect.PointerTo(t).Implements(typeJSONMarshaler) {
+			// the representation is unknown
+			return &OpenAPISchema{}
+		}
+		if reflect.PointerTo(t).Implements(typeTextMarshaler) {
+			return &OpenAPISchema{Type: "string"}
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := g.schemaOfType(t.Elem())
+		if schema.Ref != "" {
+			return schema
+		}
+		schema.Nullable = true
+
+		return schema
+
+	case reflect.Bool:
+		return &OpenAPISchema{Type: "boolean"}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &OpenAPISchema{Type: "integer", Format: "int32"}
+
+	case reflect.Int64, reflect.Uint64:
+		return &OpenAPISchema{Type: "integer", Format: "int64"}
+
+	case reflect.Float32:
+		return &OpenAPISchema{Type: "number", Format: "float"}
+
+	case reflect.Float64:
+		return &OpenAPISchema{Type: "number", Format: "double"}
+
+	case reflect.String:
+		return &OpenAPISchema{Type: "string"}
+
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// byte slices are base64 encoded
+			return &OpenAPISchema{Type: "string", Format: "byte"}
+		}
+
+		return &OpenAPISchema{Type: "array", Items: g.schemaOfType(t.Elem())}
+
+	case reflect.Map:
+		return &OpenAPISchema{Type: "object", AdditionalProperties: g.schemaOfType(t.Elem())}
+
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+
+		name := g.componentName(t)
+		if _, exists := g.schemas[name]; !exists {
+			// register before the fields are resolved, the type may be recursive
+			g.schemas[name] = &OpenAPISchema{}
+			*g.schemas[name] = *g.structSchema(t)
+		}
+
+		return &OpenAPISchema{Ref: "#/components/schemas/" + name}
+
+	default:
+		// interfaces, channels and functions
+		return &OpenAPISchema{}
+	}
+}
+
+// structSchema returns the schema of the struct fields following the rules of encoding/json.
+func (g *openAPISchemaGenerator) structSchema(t reflect.Type) *OpenAPISchema {
+	schema := &OpenAPISchema{
+		Type:       "object",
+		Properties: make(map[string]*OpenAPISchema),
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Pointer {
+				fieldType = fieldType.Elem()
+			}
+
+			if fieldType.Kind() == reflect.Struct {
+				// the fields of embedded structs are promoted
+				embedded := g.structSchema(fieldType)
+				for embeddedName, embeddedSchema := range embedded.Properties {
+					schema.Properties[embeddedName] = embeddedSchema
+				}
+				schema.Required = append(schema.Required, embedded.Required...)
+
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldSchema := g.schemaOfType(field.Type)
+		if strings.Contains(options, "string") {
+			fieldSchema = &OpenAPISchema{Type: "string"}
+		}
+		schema.Properties[name] = fieldSchema
+
+		if !strings.Contains(options, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	sort.Strings(schema.Required)
+
+	return schema
+}
+
+// openAPIDocumentBuilder assembles the OpenAPI document of the dashboard.
+type openAPIDocumentBuilder struct {
+	doc     *OpenAPIDocument
+	schemas *openAPISchemaGenerator
+}
+
+func (b *openAPIDocumentBuilder) addOperation(path string, method string, operation *OpenAPIOperation) {
+	if _, exists := b.doc.Paths[path]; !exists {
+		b.doc.Paths[path] = make(map[string]*OpenAPIOperation)
+	}
+	b.doc.Paths[path][strings.ToLower(method)] = operation
+}
+
+func (b *openAPIDocumentBuilder) jsonContent(value any) map[string]*OpenAPIMediaType {
+	return map[string]*OpenAPIMediaType{
+		echo.MIMEApplicationJSON: {Schema: b.schemas.SchemaOf(value)},
+	}
+}
+
+// addAPIRoute adds a route of the API group, public routes are matched against the given regexes.
+func (b *openAPIDocumentBuilder) addAPIRoute(route string, method string, public bool, operation *OpenAPIOperation) {
+	path, pathParameters := openAPIPath(APIBasePath + route)
+
+	for _, parameter := range pathParameters {
+		operation.Parameters = append(operation.Parameters, &OpenAPIParameter{
+			Name:     parameter,
+			In:       "path",
+			Required: true,
+			Schema:   &OpenAPISchema{Type: "string"},
+		})
+	}
+
+	if !public {
+		operation.Security = []map[string][]string{{openAPISecuritySchemeJWT: {}}}
+		operation.Responses[fmt.Sprintf("%d", http.StatusUnauthorized)] = &OpenAPIResponse{Description: "Missing or invalid JWT."}
+	}
+
+	b.addOperation("/dashboard"+path, method, operation)
+}
+
+// NewOpenAPIDocument describes the auth route, the websocket topics, the dashboard routes and the given forwarded node API routes.
+func NewOpenAPIDocument(version string, proxyRoutes []*ProxyRoute) *OpenAPIDocument {
+	b := &openAPIDocumentBuilder{
+		doc: &OpenAPIDocument{
+			OpenAPI: OpenAPIVersion,
+			Info: &OpenAPIInfo{
+				Title:       "INX-Dashboard",
+				Description: "The API of the dashboard. Routes below /dashboard/api/core, /dashboard/api/indexer and the other node API features are forwarded to the node.",
+				Version:     version,
+			},
+			Paths: make(map[string]map[string]*OpenAPIOperation),
+		},
+		schemas: newOpenAPISchemaGenerator(),
+	}
+
+	// auth
+	b.addOperation("/dashboard/auth", http.MethodPost, &OpenAPIOperation{
+		Summary:     "Issues a JWT for the credentials or renews a valid JWT.",
+		Tags:        []string{"auth"},
+		RequestBody: &OpenAPIRequestBody{Required: true, Content: b.jsonContent(&loginRequest{})},
+		Responses: map[string]*OpenAPIResponse{
+			fmt.Sprintf("%d", http.StatusOK):              {Description: "The issued JWT.", Content: b.jsonContent(&loginResponse{})},
+			fmt.Sprintf("%d", http.StatusUnauthorized):    {Description: "Invalid credentials or JWT."},
+			fmt.Sprintf("%d", http.StatusTooManyRequests): {Description: "Too many login attempts."},
+		},
+	})
+
+	// websocket
+	messages := make([]*OpenAPISchema, 0, len(websocketTopics))
+	for _, topic := range websocketTopics {
+		visibility := "public"
+		if isProtectedTopic(topic.Type) {
+			visibility = "protected"
+		}
+
+		name := "WebSocketMsg" + topic.Name
+		b.schemas.schemas[name] = &OpenAPISchema{
+			Type:        "object",
+			Description: fmt.Sprintf("Message of the %s topic %d.", visibility, topic.Type),
+			Properties: map[string]*OpenAPISchema{
+				"type": {Type: "integer", Enum: []any{topic.Type}},
+				"data": b.schemas.SchemaOf(topic.Data),
+			},
+			Required: []string{"data", "type"},
+		}
+		messages = append(messages, &OpenAPISchema{Ref: "#/components/schemas/" + name})
+	}
+
+	b.addOperation("/dashboard/ws", http.MethodGet, &OpenAPIOperation{
+		Summary:     "Opens the websocket of the dashboard.",
+		Description: websocketDescription,
+		Tags:        []string{"websocket"},
+		Responses: map[string]*OpenAPIResponse{
+			fmt.Sprintf("%d", http.StatusSwitchingProtocols): {
+				Description: "The websocket connection was established.",
+				Content: map[string]*OpenAPIMediaType{
+					echo.MIMEApplicationJSON: {Schema: &OpenAPISchema{OneOf: messages}},
+				},
+			},
+		},
+	})
+
+	// dashboard routes
+	for _, route := range dashboardRoutes {
+		operation := &OpenAPIOperation{
+			Summary: route.Summary,
+			Tags:    []string{"dashboard"},
+			Responses: map[string]*OpenAPIResponse{
+				fmt.Sprintf("%d", http.StatusOK):         {Description: "Successful operation.", Content: b.jsonContent(route.Response)},
+				fmt.Sprintf("%d", http.StatusBadRequest): {Description: "Invalid parameter."},
+			},
+		}
+
+		for _, parameter := range route.QueryParameters {
+			operation.Parameters = append(operation.Parameters, &OpenAPIParameter{
+				Name:   parameter,
+				In:     "query",
+				Schema: &OpenAPISchema{Type: "string"},
+			})
+		}
+
+		b.addAPIRoute(route.Path, route.Method, isPublicAPIRoute(route.Method, route.Path), operation)
+	}
+
+	b.addAPIRoute(RouteOpenAPI, http.MethodGet, isPublicAPIRoute(http.MethodGet, RouteOpenAPI), &OpenAPIOperation{
+		Summary: "Returns this OpenAPI specification.",
+		Tags:    []string{"dashboard"},
+		Responses: map[string]*OpenAPIResponse{
+			fmt.Sprintf("%d", http.StatusOK): {Description: "Successful operation.", Content: map[string]*OpenAPIMediaType{
+				echo.MIMEApplicationJSON: {Schema: &OpenAPISchema{Type: "object"}},
+			}},
+		},
+	})
+
+	// forwarded node API routes
+	for _, route := range proxyRoutes {
+		tag := route.Feature
+		if tag == "" {
+			tag = "node"
+		}
+
+		description := "Forwarded to the node."
+		if route.Cacheable {
+			description += " Successful responses are cached by the dashboard."
+		}
+
+		responses := map[string]*OpenAPIResponse{
+			fmt.Sprintf("%d", http.StatusOK):              {Description: "The response of the node."},
+			fmt.Sprintf("%d", http.StatusNotFound):        {Description: "The resource or the feature is not available on the node."},
+			fmt.Sprintf("%d", http.StatusTooManyRequests): {Description: "The rate limit of the client was exceeded."},
+			fmt.Sprintf("%d", http.StatusBadGateway):      {Description: "The request to the node failed."},
+			fmt.Sprintf("%d", http.StatusGatewayTimeout):  {Description: "The node did not respond in time."},
+		}
+
+		operation := &OpenAPIOperation{
+			Summary:     fmt.Sprintf("%s %s", route.Method, route.Path),
+			Description: description,
+			Tags:        []string{tag},
+			Responses:   responses,
+		}
+
+		if route.Method == http.MethodPost || route.Method == http.MethodPut {
+			operation.RequestBody = &OpenAPIRequestBody{
+				Required: true,
+				Content: map[string]*OpenAPIMediaType{
+					echo.MIMEApplicationJSON: {Schema: &OpenAPISchema{}},
+				},
+			}
+		}
+
+		b.addAPIRoute(route.Path, route.Method, route.Auth == RouteAuthPublic, operation)
+	}
+
+	b.doc.Components = &OpenAPIComponents{
+		Schemas: b.schemas.schemas,
+		SecuritySchemes: map[string]*OpenAPISecurityScheme{
+			openAPISecuritySchemeJWT: {
+				Type:         "http",
+				Scheme:       "bearer",
+				BearerFormat: "JWT",
+				Description:  "The JWT issued by /dashboard/auth.",
+			},
+		},
+	}
+
+	return b.doc
+}
+
+func (d *Dashboard) openAPIRoute(c echo.Context) error {
+	return c.JSON(http.StatusOK, NewOpenAPIDocument(d.appVersion, d.routeRegistry.Routes()))
+}
@@ -0,0 +1,58 @@
+package dashboard
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestWebsocketTopicsDescribeAllMessageTypes(t *testing.T) {
+	described := make(map[WebSocketMsgType]int)
+	for _, topic := range websocketTopics {
+		described[topic.Type]++
+	}
+
+	for msgType := WebSocketMsgType(0); msgType < msgTypeCount; msgType++ {
+		switch described[msgType] {
+		case 0:
+			t.Errorf("message type %d is not described in the websocket topics", msgType)
+		case 1:
+		default:
+			t.Errorf("message type %d is described %d times in the websocket topics", msgType, described[msgType])
+		}
+	}
+
+	if len(websocketTopics) != int(msgTypeCount) {
+		t.Errorf("expected %d websocket topics, got %d", msgTypeCount, len(websocketTopics))
+	}
+}
+
+// TestOpenAPIDocumentIsUpToDate checks that the committed specification matches the generated one.
+// Regenerate it with "go run ." in tools/gendoc.
+func TestOpenAPIDocumentIsUpToDate(t *testing.T) {
+	committed, err := os.ReadFile("../../openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the version is taken from the app, which can't be imported here
+	var committedDoc OpenAPIDocument
+	if err := json.Unmarshal(committed, &committedDoc); err != nil {
+		t.Fatal(err)
+	}
+
+	registry, err := NewRouteRegistry(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	generated, err := json.MarshalIndent(NewOpenAPIDocument(committedDoc.Info.Version, registry.Routes()), "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(committed, append(generated, '\n')) {
+		t.Error("openapi.json is outdated, regenerate it with \"go run .\" in tools/gendoc")
+	}
+}
@@ -0,0 +1,255 @@
+package dashboard
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/iota.go/v3/nodeclient"
+)
+
+const (
+	// PeerHistoryMaxEvents is the maximum amount of connectivity events kept per peer.
+	PeerHistoryMaxEvents = 100
+	// PeerHistoryMaxSamples is the maximum amount of gossip samples kept per peer.
+	PeerHistoryMaxSamples = 300
+	// PeerHistoryInterval is the interval in which the peers are polled for the history if no client is connected.
+	PeerHistoryInterval = 10 * time.Second
+	// PeerHistoryWindow is the time span covered by the gossip samples.
+	// Peers that are no longer known to the node are forgotten once they have been absent for longer.
+	PeerHistoryWindow = PeerHistoryMaxSamples * PeerHistoryInterval
+)
+
+// PeerConnectivityEvent signals that a peer connected or disconnected.
+type PeerConnectivityEvent struct {
+	ID        string `json:"id"`
+	Alias     string `json:"alias,omitempty"`
+	Connected bool   `json:"connected"`
+	Timestamp int64  `json:"ts"`
+}
+
+// PeerGossipSample holds the gossip throughput of a peer since the previous sample.
+type PeerGossipSample struct {
+	Timestamp      int64  `json:"ts"`
+	NewBlocks      uint32 `json:"newBlocks"`
+	ReceivedBlocks uint32 `json:"receivedBlocks"`
+	SentBlocks     uint32 `json:"sentBlocks"`
+	DroppedPackets uint32 `json:"droppedPackets"`
+}
+
+// PeerHistoryEntry represents the connectivity timeline of a single peer.
+type PeerHistoryEntry struct {
+	ID        string `json:"id"`
+	Alias     string `json:"alias,omitempty"`
+	Connected bool   `json:"connected"`
+	FirstSeen int64  `json:"firstSeen"`
+	LastSeen  int64  `json:"lastSeen"`
+	// Uptime is the percentage of the observed time the peer was connected.
+	Uptime        float64                  `json:"uptime"`
+	Events        []*PeerConnectivityEvent `json:"events"`
+	GossipSamples []*PeerGossipSample      `json:"gossipSamples"`
+
+	connectedDuration time.Duration
+	observedDuration  time.Duration
+	lastUpdate        time.Time
+	lastMetrics       *nodeclient.PeerGossipMetrics
+}
+
+// PeerHistory tracks the connectivity and the gossip throughput of all peers over time.
+type PeerHistory struct {
+	sync.RWMutex
+
+	peers      map[string]*PeerHistoryEntry
+	lastUpdate time.Time
+}
+
+func NewPeerHistory() *PeerHistory {
+	return &PeerHistory{
+		peers: make(map[string]*PeerHistoryEntry),
+	}
+}
+
+// counterDelta returns the difference between two gossip counters.
+// The counters are reset if a peer reconnects, in that case the current value is the delta.
+func counterDelta(current uint32, previous uint32) uint32 {
+	if current < previous {
+		return current
+	}
+
+	return current - previous
+}
+
+// Update applies the current peer infos and returns the connectivity events that happened since the last update.
+// Peers that are no longer known to the node are treated as disconnected,
+// and removed from the history once they have been absent for longer than PeerHistoryWindow.
+func (h *PeerHistory) Update(peerInfos []*nodeclient.PeerResponse, now time.Time) []*PeerConnectivityEvent {
+	h.Lock()
+	defer h.Unlock()
+
+	h.lastUpdate = now
+	seen := make(map[string]struct{}, len(peerInfos))
+
+	var events []*PeerConnectivityEvent
+	for _, peerInfo := range peerInfos {
+		seen[peerInfo.ID] = struct{}{}
+
+		entry, exists := h.peers[peerInfo.ID]
+		if !exists {
+			entry = &PeerHistoryEntry{
+				ID:            peerInfo.ID,
+				FirstSeen:     now.Unix(),
+				Events:        make([]*PeerConnectivityEvent, 0),
+				GossipSamples: make([]*PeerGossipSample, 0),
+				lastUpdate:    now,
+			}
+			h.peers[peerInfo.ID] = entry
+		}
+
+		entry.LastSeen = now.Unix()
+		if peerInfo.Alias != nil {
+			entry.Alias = *peerInfo.Alias
+		}
+
+		if event := entry.update(peerInfo.Connected, now); event != nil {
+			events = append(events, event)
+		}
+
+		if peerInfo.Gossip != nil {
+			entry.addGossipSample(&peerInfo.Gossip.Metrics, now)
+		}
+	}
+
+	for peerID, entry := range h.peers {
+		if _, exists := seen[peerID]; exists {
+			continue
+		}
+
+		if event := entry.update(false, now); event != nil {
+			events = append(events, event)
+		}
+		entry.lastMetrics = nil
+
+		if now.Sub(time.Unix(entry.LastSeen, 0)) > PeerHistoryWindow {
+			delete(h.peers, peerID)
+		}
+	}
+
+	return events
+}
+
+func (e *PeerHistoryEntry) update(connected bool, now time.Time) *PeerConnectivityEvent {
+	// the state since the last update is accounted to the previous connection state
+	elapsed := now.Sub(e.lastUpdate)
+	e.observedDuration += elapsed
+	if e.Connected {
+		e.connectedDuration += elapsed
+	}
+	e.lastUpdate = now
+
+	if e.observedDuration > 0 {
+		e.Uptime = 100 * float64(e.connectedDuration) / float64(e.observedDuration)
+	} else if connected {
+		e.Uptime = 100
+	}
+
+	if e.Connected == connected {
+		return nil
+	}
+	e.Connected = connected
+
+	event := &PeerConnectivityEvent{
+		ID:        e.ID,
+		Alias:     e.Alias,
+		Connected: connected,
+		Timestamp: now.Unix(),
+	}
+
+	e.Events = append(e.Events, event)
+	if len(e.Events) > PeerHistoryMaxEvents {
+		e.Events = e.Events[len(e.Events)-PeerHistoryMaxEvents:]
+	}
+
+	return event
+}
+
+func (e *PeerHistoryEntry) addGossipSample(metrics *nodeclient.PeerGossipMetrics, now time.Time) {
+	previous := e.lastMetrics
+	metricsCopy := *metrics
+	e.lastMetrics = &metricsCopy
+
+	if previous == nil {
+		// we need two measurements to calculate the deltas
+		return
+	}
+
+	e.GossipSamples = append(e.GossipSamples, &PeerGossipSample{
+		Timestamp:      now.Unix(),
+		NewBlocks:      counterDelta(metrics.NewBlocks, previous.NewBlocks),
+		ReceivedBlocks: counterDelta(metrics.ReceivedBlocks, previous.ReceivedBlocks),
+		SentBlocks:     counterDelta(metrics.SentBlocks, previous.SentBlocks),
+		DroppedPackets: counterDelta(metrics.DroppedPackets, previous.DroppedPackets),
+	})
+	if len(e.GossipSamples) > PeerHistoryMaxSamples {
+		e.GossipSamples = e.GossipSamples[len(e.GossipSamples)-PeerHistoryMaxSamples:]
+	}
+}
+
+// ConnectedPeers returns the amount of connected peers and the time of the last update.
+// The time is zero if the history was never updated.
+func (h *PeerHistory) ConnectedPeers() (int, time.Time) {
+	h.RLock()
+	defer h.RUnlock()
+
+	connectedPeers := 0
+	for _, entry := range h.peers {
+		if entry.Connected {
+			connectedPeers++
+		}
+	}
+
+	return connectedPeers, h.lastUpdate
+}
+
+// Events returns the connectivity events of all peers, sorted by their timestamp.
+func (h *PeerHistory) Events() []*PeerConnectivityEvent {
+	h.RLock()
+	defer h.RUnlock()
+
+	events := make([]*PeerConnectivityEvent, 0)
+	for _, entry := range h.peers {
+		events = append(events, entry.Events...)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp < events[j].Timestamp
+	})
+
+	return events
+}
+
+// Entries returns a copy of the history of all peers, sorted by their ID.
+func (h *PeerHistory) Entries() []*PeerHistoryEntry {
+	h.RLock()
+	defer h.RUnlock()
+
+	entries := make([]*PeerHistoryEntry, 0, len(h.peers))
+	for _, entry := range h.peers {
+		entryCopy := *entry
+		entryCopy.Events = append(make([]*PeerConnectivityEvent, 0, len(entry.Events)), entry.Events...)
+		entryCopy.GossipSamples = append(make([]*PeerGossipSample, 0, len(entry.GossipSamples)), entry.GossipSamples...)
+		entries = append(entries, &entryCopy)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ID < entries[j].ID
+	})
+
+	return entries
+}
+
+func (d *Dashboard) peersHistoryRoute(c echo.Context) error {
+	return c.JSON(http.StatusOK, d.peerHistory.Entries())
+}
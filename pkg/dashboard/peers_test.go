@@ -0,0 +1,185 @@
+package dashboard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/iota.go/v3/nodeclient"
+)
+
+// testPeerInfo returns the info of a peer whose gossip counters all have the given value.
+func testPeerInfo(id string, connected bool, counter uint32) *nodeclient.PeerResponse {
+	return &nodeclient.PeerResponse{
+		ID:        id,
+		Connected: connected,
+		Gossip: &nodeclient.GossipInfo{
+			Metrics: nodeclient.PeerGossipMetrics{
+				NewBlocks:      counter,
+				ReceivedBlocks: counter,
+				SentBlocks:     counter,
+				DroppedPackets: counter,
+			},
+		},
+	}
+}
+
+func TestPeerHistoryUpdate(t *testing.T) {
+	type step struct {
+		// elapsed is the time since the first update.
+		elapsed time.Duration
+		peers   []*nodeclient.PeerResponse
+		// wantEvents are the IDs of the peers that connected (+) or disconnected (-), e.g. "a+".
+		wantEvents []string
+	}
+
+	type wantEntry struct {
+		id        string
+		connected bool
+		uptime    float64
+		// samples are the new blocks of the gossip samples.
+		samples []uint32
+		events  int
+	}
+
+	tests := []struct {
+		name  string
+		steps []step
+		want  []wantEntry
+	}{
+		{
+			name: "new peer",
+			steps: []step{
+				{peers: []*nodeclient.PeerResponse{testPeerInfo("a", true, 0)}, wantEvents: []string{"a+"}},
+			},
+			want: []wantEntry{{id: "a", connected: true, uptime: 100, events: 1}},
+		},
+		{
+			name: "unchanged state",
+			steps: []step{
+				{peers: []*nodeclient.PeerResponse{testPeerInfo("a", true, 0)}, wantEvents: []string{"a+"}},
+				{elapsed: 10 * time.Second, peers: []*nodeclient.PeerResponse{testPeerInfo("a", true, 0)}},
+				{elapsed: 20 * time.Second, peers: []*nodeclient.PeerResponse{testPeerInfo("a", true, 0)}},
+			},
+			want: []wantEntry{{id: "a", connected: true, uptime: 100, samples: []uint32{0, 0}, events: 1}},
+		},
+		{
+			name: "uptime",
+			steps: []step{
+				{peers: []*nodeclient.PeerResponse{testPeerInfo("a", true, 0)}, wantEvents: []string{"a+"}},
+				{elapsed: 10 * time.Second, peers: []*nodeclient.PeerResponse{testPeerInfo("a", false, 0)}, wantEvents: []string{"a-"}},
+				{elapsed: 20 * time.Second, peers: []*nodeclient.PeerResponse{testPeerInfo("a", false, 0)}},
+				{elapsed: 40 * time.Second, peers: []*nodeclient.PeerResponse{testPeerInfo("a", true, 0)}, wantEvents: []string{"a+"}},
+			},
+			want: []wantEntry{{id: "a", connected: true, uptime: 25, samples: []uint32{0, 0, 0}, events: 3}},
+		},
+		{
+			name: "counter deltas",
+			steps: []step{
+				{peers: []*nodeclient.PeerResponse{testPeerInfo("a", true, 10)}, wantEvents: []string{"a+"}},
+				{elapsed: 10 * time.Second, peers: []*nodeclient.PeerResponse{testPeerInfo("a", true, 15)}},
+				// the counters were reset by a reconnect
+				{elapsed: 20 * time.Second, peers: []*nodeclient.PeerResponse{testPeerInfo("a", true, 3)}},
+			},
+			want: []wantEntry{{id: "a", connected: true, uptime: 100, samples: []uint32{5, 3}, events: 1}},
+		},
+		{
+			name: "removed peer",
+			steps: []step{
+				{peers: []*nodeclient.PeerResponse{testPeerInfo("a", true, 0), testPeerInfo("b", true, 10)}, wantEvents: []string{"a+", "b+"}},
+				{elapsed: 10 * time.Second, peers: []*nodeclient.PeerResponse{testPeerInfo("a", true, 0)}, wantEvents: []string{"b-"}},
+				// the counters of the re-added peer start from scratch
+				{elapsed: 20 * time.Second, peers: []*nodeclient.PeerResponse{testPeerInfo("a", true, 0), testPeerInfo("b", true, 2)}, wantEvents: []string{"b+"}},
+				{elapsed: 30 * time.Second, peers: []*nodeclient.PeerResponse{testPeerInfo("a", true, 0), testPeerInfo("b", true, 6)}},
+			},
+			want: []wantEntry{
+				{id: "a", connected: true, uptime: 100, samples: []uint32{0, 0, 0}, events: 1},
+				{id: "b", connected: true, uptime: 200.0 / 3, samples: []uint32{4}, events: 3},
+			},
+		},
+		{
+			name: "removed peer within the window",
+			steps: []step{
+				{peers: []*nodeclient.PeerResponse{testPeerInfo("a", true, 0), testPeerInfo("b", true, 0)}, wantEvents: []string{"a+", "b+"}},
+				{elapsed: 10 * time.Second, peers: []*nodeclient.PeerResponse{testPeerInfo("a", true, 0)}, wantEvents: []string{"b-"}},
+				{elapsed: PeerHistoryWindow, peers: []*nodeclient.PeerResponse{testPeerInfo("a", true, 0)}},
+			},
+			want: []wantEntry{
+				{id: "a", connected: true, uptime: 100, samples: []uint32{0, 0}, events: 1},
+				{id: "b", connected: false, uptime: 100 * float64(10*time.Second) / float64(PeerHistoryWindow), events: 2},
+			},
+		},
+		{
+			name: "removed peer evicted after the window",
+			steps: []step{
+				{peers: []*nodeclient.PeerResponse{testPeerInfo("a", true, 0), testPeerInfo("b", true, 0)}, wantEvents: []string{"a+", "b+"}},
+				{elapsed: 10 * time.Second, peers: []*nodeclient.PeerResponse{testPeerInfo("a", true, 0)}, wantEvents: []string{"b-"}},
+				{elapsed: PeerHistoryWindow + time.Second, peers: []*nodeclient.PeerResponse{testPeerInfo("a", true, 0)}},
+			},
+			want: []wantEntry{
+				{id: "a", connected: true, uptime: 100, samples: []uint32{0, 0}, events: 1},
+			},
+		},
+		{
+			name: "connected peer evicted after the window",
+			steps: []step{
+				{peers: []*nodeclient.PeerResponse{testPeerInfo("a", true, 0)}, wantEvents: []string{"a+"}},
+				// the peer is still reported as disconnected before it is forgotten
+				{elapsed: PeerHistoryWindow + time.Second, wantEvents: []string{"a-"}},
+			},
+			want: []wantEntry{},
+		},
+	}
+
+	start := time.Unix(1_600_000_000, 0)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history := NewPeerHistory()
+
+			for i, step := range tt.steps {
+				events := history.Update(step.peers, start.Add(step.elapsed))
+
+				if len(events) != len(step.wantEvents) {
+					t.Fatalf("step %d: expected %d events, got %d", i, len(step.wantEvents), len(events))
+				}
+				for j, event := range events {
+					got := event.ID + "-"
+					if event.Connected {
+						got = event.ID + "+"
+					}
+					if got != step.wantEvents[j] {
+						t.Errorf("step %d: expected event %s, got %s", i, step.wantEvents[j], got)
+					}
+					if event.Timestamp != start.Add(step.elapsed).Unix() {
+						t.Errorf("step %d: expected event timestamp %d, got %d", i, start.Add(step.elapsed).Unix(), event.Timestamp)
+					}
+				}
+			}
+
+			entries := history.Entries()
+			if len(entries) != len(tt.want) {
+				t.Fatalf("expected %d entries, got %d", len(tt.want), len(entries))
+			}
+			for i, entry := range entries {
+				want := tt.want[i]
+				if entry.ID != want.id || entry.Connected != want.connected {
+					t.Errorf("entry %d: expected %s (connected: %t), got %s (connected: %t)", i, want.id, want.connected, entry.ID, entry.Connected)
+				}
+				if diff := entry.Uptime - want.uptime; diff > 1e-9 || diff < -1e-9 {
+					t.Errorf("entry %s: expected an uptime of %f, got %f", entry.ID, want.uptime, entry.Uptime)
+				}
+				if len(entry.Events) != want.events {
+					t.Errorf("entry %s: expected %d events, got %d", entry.ID, want.events, len(entry.Events))
+				}
+				if len(entry.GossipSamples) != len(want.samples) {
+					t.Fatalf("entry %s: expected %d gossip samples, got %d", entry.ID, len(want.samples), len(entry.GossipSamples))
+				}
+				for j, sample := range entry.GossipSamples {
+					if sample.NewBlocks != want.samples[j] || sample.DroppedPackets != want.samples[j] {
+						t.Errorf("entry %s: expected gossip sample %d to be %d, got %+v", entry.ID, j, want.samples[j], sample)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package dashboard
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	jwtgo "github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/inx-dashboard/pkg/jwt"
+	"github.com/iotaledger/inx-dashboard/pkg/ratelimit"
+)
+
+const (
+	// rateLimitExpiresIn is the time after which inactive clients are forgotten by the rate limiter.
+	rateLimitExpiresIn = 5 * time.Minute
+	// rateLimitMaxClients is the maximum amount of clients tracked separately by a budget.
+	rateLimitMaxClients = 100000
+)
+
+// ipExtractor returns the extractor of the client IPs used for rate limiting.
+// Forwarded headers can be set by any client, they are only trusted if sent by the configured proxies.
+func (d *Dashboard) ipExtractor() echo.IPExtractor {
+	var trustedRanges []echo.TrustOption
+	for _, trustedProxy := range d.trustedProxies {
+		if strings.TrimSpace(trustedProxy) == "" {
+			continue
+		}
+
+		_, ipRange, err := net.ParseCIDR(strings.TrimSpace(trustedProxy))
+		if err != nil {
+			d.LogErrorfAndExit("invalid trusted proxy IP range %s: %s", trustedProxy, err)
+		}
+		trustedRanges = append(trustedRanges, echo.TrustIPRange(ipRange))
+	}
+
+	if len(trustedRanges) == 0 {
+		if d.proxyRateLimitEnabled {
+			d.LogWarn("no trusted proxies configured, clients are rate limited by the IP of their connection. If the dashboard runs behind a reverse proxy, all clients share the rate limit of the proxy")
+		}
+
+		return echo.ExtractIPDirect()
+	}
+
+	// only the configured ranges are trusted, not the local networks trusted by default
+	return echo.ExtractIPFromXFFHeader(append([]echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}, trustedRanges...)...)
+}
+
+// proxyRateLimiter holds the budgets of the proxied node API routes.
+// Anonymous clients are identified by their IP, authenticated clients by the subject of their JWT.
+// Indexer queries are expensive for the node, therefore they have separate budgets.
+type proxyRateLimiter struct {
+	ip             *ratelimit.Budget
+	ipIndexer      *ratelimit.Budget
+	subject        *ratelimit.Budget
+	subjectIndexer *ratelimit.Budget
+}
+
+func (d *Dashboard) newProxyRateLimiter() *proxyRateLimiter {
+	return &proxyRateLimiter{
+		ip:             ratelimit.NewBudget("ip", d.proxyRateLimitMaxRequests, d.proxyRateLimitPeriod, d.proxyRateLimitMaxBurst, rateLimitExpiresIn, rateLimitMaxClients),
+		ipIndexer:      ratelimit.NewBudget("ip_indexer", d.proxyRateLimitIndexerMaxRequests, d.proxyRateLimitPeriod, d.proxyRateLimitIndexerMaxBurst, rateLimitExpiresIn, rateLimitMaxClients),
+		subject:        ratelimit.NewBudget("subject", d.proxyRateLimitAuthenticatedMaxRequests, d.proxyRateLimitPeriod, d.proxyRateLimitAuthenticatedMaxBurst, rateLimitExpiresIn, rateLimitMaxClients),
+		subjectIndexer: ratelimit.NewBudget("subject_indexer", d.proxyRateLimitAuthenticatedIndexerMaxRequests, d.proxyRateLimitPeriod, d.proxyRateLimitAuthenticatedIndexerMaxBurst, rateLimitExpiresIn, rateLimitMaxClients),
+	}
+}
+
+// authenticatedSubject returns the subject of a valid JWT sent with the request.
+// Protected routes were already verified by the JWT middleware, public routes may optionally send a JWT.
+func (d *Dashboard) authenticatedSubject(c echo.Context) (string, bool) {
+	if token, ok := c.Get("jwt").(*jwtgo.Token); ok {
+		if claims, ok := token.Claims.(*jwt.AuthClaims); ok {
+			return claims.Subject, true
+		}
+	}
+
+	authorization := c.Request().Header.Get(echo.HeaderAuthorization)
+	if !strings.HasPrefix(authorization, "Bearer ") {
+		return "", false
+	}
+
+	var subject string
+	if !d.jwtAuth.VerifyJWT(strings.TrimPrefix(authorization, "Bearer "), func(claims *jwt.AuthClaims) bool {
+		subject = claims.Subject
+
+		return true
+	}) {
+		return "", false
+	}
+
+	return subject, true
+}
+
+// checkRateLimit consumes a token of the client for the given route.
+// If the budget of the client is exhausted, a 429 error with a Retry-After header is returned.
+func (d *Dashboard) checkRateLimit(c echo.Context, route *ProxyRoute) error {
+	if d.proxyRateLimiter == nil {
+		return nil
+	}
+
+	indexer := route.Feature == FeatureIndexer
+
+	budget := d.proxyRateLimiter.ip
+	if indexer {
+		budget = d.proxyRateLimiter.ipIndexer
+	}
+	clientID := c.RealIP()
+
+	if subject, authenticated := d.authenticatedSubject(c); authenticated {
+		budget = d.proxyRateLimiter.subject
+		if indexer {
+			budget = d.proxyRateLimiter.subjectIndexer
+		}
+		clientID = subject
+	}
+
+	allowed, retryAfter := budget.Allow(clientID)
+	if allowed {
+		return nil
+	}
+
+	c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+
+	return echo.NewHTTPError(http.StatusTooManyRequests, fmt.Sprintf("rate limit exceeded, retry after %s", retryAfter.Round(time.Second)))
+}
+
+// RateLimitMetrics returns the state of the rate limit budgets of the proxied routes, or nil if rate limiting is disabled.
+func (d *Dashboard) RateLimitMetrics() []*ratelimit.Metrics {
+	if d.proxyRateLimiter == nil {
+		return nil
+	}
+
+	return []*ratelimit.Metrics{
+		d.proxyRateLimiter.ip.Metrics(),
+		d.proxyRateLimiter.ipIndexer.Metrics(),
+		d.proxyRateLimiter.subject.Metrics(),
+		d.proxyRateLimiter.subjectIndexer.Metrics(),
+	}
+}
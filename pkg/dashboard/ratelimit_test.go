@@ -0,0 +1,70 @@
+package dashboard
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/hive.go/logger"
+)
+
+func TestIPExtractor(t *testing.T) {
+	const client = "203.0.113.5"
+
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		want           string
+	}{
+		{
+			name:       "no trusted proxies",
+			remoteAddr: "10.0.0.1:1234",
+			want:       "10.0.0.1",
+		},
+		{
+			name:           "empty trusted proxies",
+			trustedProxies: []string{""},
+			remoteAddr:     "10.0.0.1:1234",
+			want:           "10.0.0.1",
+		},
+		{
+			name:           "trusted proxy",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "10.0.0.1:1234",
+			want:           client,
+		},
+		{
+			name:           "untrusted proxy",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "192.168.0.1:1234",
+			want:           "192.168.0.1",
+		},
+		{
+			name:           "loopback is not trusted by default",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "127.0.0.1:1234",
+			want:           "127.0.0.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Dashboard{
+				WrappedLogger:         logger.NewWrappedLogger(nil),
+				trustedProxies:        tt.trustedProxies,
+				proxyRateLimitEnabled: true,
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set(echo.HeaderXForwardedFor, client)
+
+			if ip := d.ipExtractor()(req); ip != tt.want {
+				t.Errorf("expected client IP %s, got %s", tt.want, ip)
+			}
+		})
+	}
+}
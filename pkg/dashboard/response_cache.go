@@ -0,0 +1,67 @@
+package dashboard
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/inx-dashboard/pkg/cache"
+)
+
+const (
+	// HeaderXCache signals whether a response was served from the response cache.
+	HeaderXCache = "X-Cache"
+
+	XCacheHit  = "HIT"
+	XCacheMiss = "MISS"
+)
+
+// cachedResponse is a successful node API response stored in the response cache.
+type cachedResponse struct {
+	Header http.Header
+	Body   []byte
+}
+
+// responseCacheKey returns the cache key of a request.
+// The "Accept" header is part of the key, because the node serves JSON and binary representations on the same route.
+func responseCacheKey(c echo.Context) string {
+	return c.Request().Method + " " + c.Request().RequestURI + " " + c.Request().Header.Get(echo.HeaderAccept)
+}
+
+// forwardCachedRequest serves immutable node API resources from the response cache
+// and forwards the request to the node on a cache miss.
+// Only successful responses are cached, resources that don't exist yet may appear later.
+func (d *Dashboard) forwardCachedRequest(c echo.Context, proxyRoute *ProxyRoute) error {
+	if d.responseCache == nil {
+		return d.forwardRequest(c, proxyRoute)
+	}
+
+	key := responseCacheKey(c)
+	if entry, exists := d.responseCache.Get(key); exists {
+		//nolint:forcetypeassert // we only store cached responses in the cache
+		response := entry.Value.(*cachedResponse)
+
+		for header, values := range response.Header {
+			c.Response().Header()[header] = values
+		}
+		c.Response().Header().Set(HeaderXCache, XCacheHit)
+
+		return c.Blob(http.StatusOK, response.Header.Get(echo.HeaderContentType), response.Body)
+	}
+
+	return d.proxyRequest(c, proxyRoute, func(response *cachedResponse) {
+		d.responseCache.Put(key, &cache.Entry{
+			Value: response,
+			Size:  int64(len(key) + len(response.Body)),
+		})
+	})
+}
+
+// ResponseCacheMetrics returns the statistics of the response cache, or nil if the cache is disabled.
+func (d *Dashboard) ResponseCacheMetrics() *cache.Metrics {
+	if d.responseCache == nil {
+		return nil
+	}
+
+	return d.responseCache.Metrics()
+}
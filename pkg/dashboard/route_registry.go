@@ -0,0 +1,342 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/runtime/timeutil"
+	"github.com/iotaledger/inx-dashboard/pkg/common"
+	"github.com/iotaledger/inx-dashboard/pkg/daemon"
+)
+
+const (
+	// RouteAuthPublic marks routes that can be called without authorization.
+	RouteAuthPublic = "public"
+	// RouteAuthProtected marks routes that need to be called with authorization.
+	RouteAuthProtected = "protected"
+
+	// proxyRouteCacheable marks routes whose responses are immutable and can be cached.
+	proxyRouteCacheable = "cacheable"
+
+	// nodeFeaturesRefreshInterval is the interval in which the features served by the node are discovered.
+	nodeFeaturesRefreshInterval = 1 * time.Minute
+)
+
+// ProxyRoute describes a node API route that is forwarded by the dashboard.
+type ProxyRoute struct {
+	// Feature is the node API feature that serves the route, e.g. "core/v2".
+	// Routes without a feature are always available.
+	Feature string `json:"feature"`
+	Method  string `json:"method"`
+	// Path is the echo route path relative to the API base path.
+	Path      string `json:"path"`
+	Auth      string `json:"auth"`
+	Cacheable bool   `json:"cacheable"`
+}
+
+// defaultProxyRoutes returns the built-in node API routes forwarded by the dashboard.
+func defaultProxyRoutes() []*ProxyRoute {
+	return []*ProxyRoute{
+		{Feature: "", Method: http.MethodGet, Path: RouteRoutes, Auth: RouteAuthPublic},
+
+		// core
+		{Feature: FeatureCoreAPI, Method: http.MethodGet, Path: RouteCoreInfo, Auth: RouteAuthPublic},
+		{Feature: FeatureCoreAPI, Method: http.MethodGet, Path: RouteCoreBlockMetadata, Auth: RouteAuthPublic},
+		{Feature: FeatureCoreAPI, Method: http.MethodGet, Path: RouteCoreBlock, Auth: RouteAuthPublic, Cacheable: true},
+		{Feature: FeatureCoreAPI, Method: http.MethodGet, Path: RouteCoreTransactionsIncludedBlock, Auth: RouteAuthPublic, Cacheable: true},
+		{Feature: FeatureCoreAPI, Method: http.MethodGet, Path: RouteCoreMilestoneByID, Auth: RouteAuthPublic, Cacheable: true},
+		{Feature: FeatureCoreAPI, Method: http.MethodGet, Path: RouteCoreMilestoneByIndex, Auth: RouteAuthPublic, Cacheable: true},
+		{Feature: FeatureCoreAPI, Method: http.MethodGet, Path: RouteCoreOutput, Auth: RouteAuthPublic},
+		{Feature: FeatureCoreAPI, Method: http.MethodDelete, Path: RouteCorePeer, Auth: RouteAuthProtected},
+		{Feature: FeatureCoreAPI, Method: http.MethodPost, Path: RouteCorePeers, Auth: RouteAuthProtected},
+
+		// indexer
+		{Feature: FeatureIndexer, Method: http.MethodGet, Path: RouteIndexerOutputsBasic, Auth: RouteAuthPublic},
+		{Feature: FeatureIndexer, Method: http.MethodGet, Path: RouteIndexerOutputsAliases, Auth: RouteAuthPublic},
+		{Feature: FeatureIndexer, Method: http.MethodGet, Path: RouteIndexerOutputsAliasByID, Auth: RouteAuthPublic},
+		{Feature: FeatureIndexer, Method: http.MethodGet, Path: RouteIndexerOutputsNFTs, Auth: RouteAuthPublic},
+		{Feature: FeatureIndexer, Method: http.MethodGet, Path: RouteIndexerOutputsNFTByID, Auth: RouteAuthPublic},
+		{Feature: FeatureIndexer, Method: http.MethodGet, Path: RouteIndexerOutputsFoundries, Auth: RouteAuthPublic},
+		{Feature: FeatureIndexer, Method: http.MethodGet, Path: RouteIndexerOutputsFoundryByID, Auth: RouteAuthPublic},
+
+		// participation
+		{Feature: FeatureParticipation, Method: http.MethodGet, Path: RouteParticipationEvents, Auth: RouteAuthProtected},
+		{Feature: FeatureParticipation, Method: http.MethodGet, Path: RouteParticipationEvent, Auth: RouteAuthProtected},
+		{Feature: FeatureParticipation, Method: http.MethodGet, Path: RouteParticipationEventStatus, Auth: RouteAuthProtected},
+		{Feature: FeatureParticipation, Method: http.MethodPost, Path: RouteParticipationAdminCreateEvent, Auth: RouteAuthProtected},
+		{Feature: FeatureParticipation, Method: http.MethodDelete, Path: RouteParticipationAdminDeleteEvent, Auth: RouteAuthProtected},
+
+		// spammer
+		{Feature: FeatureSpammer, Method: http.MethodGet, Path: RouteSpammerStatus, Auth: RouteAuthProtected},
+		{Feature: FeatureSpammer, Method: http.MethodPost, Path: RouteSpammerStart, Auth: RouteAuthProtected},
+		{Feature: FeatureSpammer, Method: http.MethodPost, Path: RouteSpammerStop, Auth: RouteAuthProtected},
+	}
+}
+
+// ParseProxyRoute parses a route in the format "<feature> <method> <path> <public|protected> [cacheable]".
+func ParseProxyRoute(route string) (*ProxyRoute, error) {
+	fields := strings.Fields(route)
+	if len(fields) < 4 || len(fields) > 5 {
+		return nil, errors.WithMessagef(common.ErrInvalidParameter, "invalid route: %q, expected format: \"<feature> <method> <path> <public|protected> [cacheable]\"", route)
+	}
+
+	proxyRoute := &ProxyRoute{
+		Feature: fields[0],
+		Method:  strings.ToUpper(fields[1]),
+		Path:    fields[2],
+		Auth:    strings.ToLower(fields[3]),
+	}
+
+	switch proxyRoute.Method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return nil, errors.WithMessagef(common.ErrInvalidParameter, "invalid method in route %q: %s", route, fields[1])
+	}
+
+	if !strings.HasPrefix(proxyRoute.Path, "/") {
+		return nil, errors.WithMessagef(common.ErrInvalidParameter, "invalid path in route %q: %s", route, proxyRoute.Path)
+	}
+
+	if proxyRoute.Auth != RouteAuthPublic && proxyRoute.Auth != RouteAuthProtected {
+		return nil, errors.WithMessagef(common.ErrInvalidParameter, "invalid auth level in route %q: %s", route, fields[3])
+	}
+
+	if len(fields) == 5 {
+		if strings.ToLower(fields[4]) != proxyRouteCacheable {
+			return nil, errors.WithMessagef(common.ErrInvalidParameter, "invalid option in route %q: %s", route, fields[4])
+		}
+		proxyRoute.Cacheable = true
+	}
+
+	return proxyRoute, nil
+}
+
+// RouteRegistry holds the node API routes forwarded by the dashboard.
+type RouteRegistry struct {
+	routes []*ProxyRoute
+}
+
+// NewRouteRegistry creates a registry with the built-in routes and the additional routes in the config format.
+// Additional routes with the same method and path replace the built-in route.
+func NewRouteRegistry(additionalRoutes []string) (*RouteRegistry, error) {
+	registry := &RouteRegistry{
+		routes: defaultProxyRoutes(),
+	}
+
+	for _, route := range additionalRoutes {
+		if strings.TrimSpace(route) == "" {
+			continue
+		}
+
+		proxyRoute, err := ParseProxyRoute(route)
+		if err != nil {
+			return nil, err
+		}
+		registry.Add(proxyRoute)
+	}
+
+	return registry, nil
+}
+
+// Add adds a route to the registry, an existing route with the same method and path is replaced.
+func (r *RouteRegistry) Add(route *ProxyRoute) {
+	for i, existing := range r.routes {
+		if existing.Method == route.Method && existing.Path == route.Path {
+			r.routes[i] = route
+
+			return
+		}
+	}
+
+	r.routes = append(r.routes, route)
+}
+
+// Routes returns all routes of the registry.
+func (r *RouteRegistry) Routes() []*ProxyRoute {
+	return r.routes
+}
+
+// routeParameterRegex matches the named parameters of echo route paths.
+var routeParameterRegex = regexp.MustCompile(`:[^/]+`)
+
+// apiRoute identifies a route by its method and its echo route path.
+type apiRoute struct {
+	Method string
+	Path   string
+}
+
+// compileAPIRouteAsRegex compiles the path of a route below the given base path into a regex matching the whole request path.
+func compileAPIRouteAsRegex(basePath string, path string) *regexp.Regexp {
+	r := regexp.QuoteMeta(basePath + path)
+	r = routeParameterRegex.ReplaceAllString(r, `[^/]+`)
+
+	return regexp.MustCompile("^" + r + "$")
+}
+
+// newRouteMatcher returns a function that checks whether the method and the path of a request match one of the routes below the base path.
+// The query string is not part of the path, it can't be used to match a route.
+func newRouteMatcher(basePath string, routes []*apiRoute) func(c echo.Context) bool {
+	type compiledRoute struct {
+		method string
+		regex  *regexp.Regexp
+	}
+
+	compiledRoutes := make([]*compiledRoute, len(routes))
+	for i, route := range routes {
+		compiledRoutes[i] = &compiledRoute{
+			method: route.Method,
+			regex:  compileAPIRouteAsRegex(basePath, route.Path),
+		}
+	}
+
+	return func(c echo.Context) bool {
+		request := c.Request()
+
+		for _, route := range compiledRoutes {
+			if route.method == request.Method && route.regex.MatchString(request.URL.Path) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// publicRouteMatcher returns a function that checks whether a request matches a public route of the registry below the base path.
+func (r *RouteRegistry) publicRouteMatcher(basePath string) func(c echo.Context) bool {
+	publicRoutes := make([]*apiRoute, 0)
+	for _, route := range r.routes {
+		if route.Auth != RouteAuthPublic {
+			continue
+		}
+
+		publicRoutes = append(publicRoutes, &apiRoute{Method: route.Method, Path: route.Path})
+	}
+
+	return newRouteMatcher(basePath, publicRoutes)
+}
+
+// NodeFeatures keeps track of the API features served by the node.
+type NodeFeatures struct {
+	sync.RWMutex
+
+	// features is nil as long as the features were not discovered yet.
+	features map[string]struct{}
+}
+
+func NewNodeFeatures() *NodeFeatures {
+	return &NodeFeatures{}
+}
+
+// Set replaces the features served by the node and returns whether they changed.
+func (f *NodeFeatures) Set(features []string) bool {
+	f.Lock()
+	defer f.Unlock()
+
+	newFeatures := make(map[string]struct{}, len(features))
+	for _, feature := range features {
+		newFeatures[feature] = struct{}{}
+	}
+
+	changed := f.features == nil || len(f.features) != len(newFeatures)
+	if !changed {
+		for feature := range newFeatures {
+			if _, exists := f.features[feature]; !exists {
+				changed = true
+
+				break
+			}
+		}
+	}
+	f.features = newFeatures
+
+	return changed
+}
+
+// Available returns whether the feature is served by the node.
+// All features are treated as available until they were discovered.
+func (f *NodeFeatures) Available(feature string) bool {
+	if feature == "" {
+		return true
+	}
+
+	f.RLock()
+	defer f.RUnlock()
+
+	if f.features == nil {
+		return true
+	}
+
+	_, exists := f.features[feature]
+
+	return exists
+}
+
+// proxyRouteHandler forwards requests of the route to the node if the node serves its feature.
+func (d *Dashboard) proxyRouteHandler(route *ProxyRoute) echo.HandlerFunc {
+	handler := func(c echo.Context) error {
+		if !d.nodeFeatures.Available(route.Feature) {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("feature %s is not available on the node", route.Feature))
+		}
+
+		if err := d.checkRateLimit(c, route); err != nil {
+			return err
+		}
+
+		if route.Cacheable {
+			return d.forwardCachedRequest(c, route)
+		}
+
+		return d.forwardRequest(c, route)
+	}
+
+	return func(c echo.Context) error {
+		ctx, span := d.startRequestSpan(c, route)
+		c.SetRequest(c.Request().WithContext(ctx))
+
+		err := handler(c)
+		endRequestSpan(span, c, err)
+
+		return err
+	}
+}
+
+func (d *Dashboard) discoverNodeFeatures(ctx context.Context) {
+	ctxNode, ctxNodeCancel := context.WithTimeout(ctx, nodeTimeout)
+	defer ctxNodeCancel()
+
+	routes, err := d.nodeClient.Routes(ctxNode)
+	if err != nil {
+		d.LogWarnf("failed to discover the features of the node: %s", err)
+
+		return
+	}
+
+	if d.nodeFeatures.Set(routes.Routes) {
+		features := append([]string{}, routes.Routes...)
+		sort.Strings(features)
+		d.LogInfof("node serves the features: %s", strings.Join(features, ", "))
+	}
+}
+
+func (d *Dashboard) runNodeFeatureDiscovery() {
+	if err := d.daemon.BackgroundWorker("Dashboard[NodeFeatures]", func(ctx context.Context) {
+		d.discoverNodeFeatures(ctx)
+
+		ticker := timeutil.NewTicker(func() {
+			d.discoverNodeFeatures(ctx)
+		}, nodeFeaturesRefreshInterval, ctx)
+		ticker.WaitForGracefulShutdown()
+	}, daemon.PriorityStopDashboard); err != nil {
+		d.LogPanicf("failed to start worker: %s", err)
+	}
+}
@@ -0,0 +1,117 @@
+package dashboard
+
+import (
+	"crypto/ed25519"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/inx-dashboard/pkg/jwt"
+)
+
+func TestRouteRegistryPublicRouteMatcher(t *testing.T) {
+	registry, err := NewRouteRegistry([]string{
+		"spammer/v1 GET /spammer/v1/status public",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matchPublic := registry.publicRouteMatcher(DashboardAPIPath)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		public bool
+	}{
+		{"static route", http.MethodGet, "/dashboard/api/core/v2/info", true},
+		{"route with parameter", http.MethodGet, "/dashboard/api/core/v2/blocks/0x1234", true},
+		{"route with query string", http.MethodGet, "/dashboard/api/indexer/v1/outputs/basic?address=rms1", true},
+		{"additional public route", http.MethodGet, "/dashboard/api/spammer/v1/status", true},
+		{"protected route", http.MethodPost, "/dashboard/api/spammer/v1/start", false},
+		{"public path with other method", http.MethodDelete, "/dashboard/api/core/v2/info", false},
+		{"public path in query string", http.MethodPost, "/dashboard/api/spammer/v1/start?x=/dashboard/api/core/v2/info", false},
+		{"public path as suffix", http.MethodGet, "/dashboard/api/participation/v1/events/dashboard/api/core/v2/info", false},
+		{"public path without base path", http.MethodGet, "/core/v2/info", false},
+		{"parameter spanning segments", http.MethodGet, "/dashboard/api/core/v2/blocks/0x1234/children", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := echo.New().NewContext(httptest.NewRequest(tt.method, tt.target, nil), httptest.NewRecorder())
+
+			if public := matchPublic(c); public != tt.public {
+				t.Errorf("expected public %v, got %v", tt.public, public)
+			}
+		})
+	}
+}
+
+func TestAPIMiddlewaresRejectUnauthorizedRequests(t *testing.T) {
+	_, secret, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jwtAuth, err := jwt.NewAuth("admin", time.Hour, "identity", secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	registry, err := NewRouteRegistry(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := &Dashboard{
+		authUsername:  "admin",
+		jwtAuth:       jwtAuth,
+		routeRegistry: registry,
+	}
+
+	ok := func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}
+
+	e := echo.New()
+	group := e.Group(DashboardAPIPath, d.apiMiddlewares()...)
+	for _, route := range registry.Routes() {
+		group.Add(route.Method, route.Path, ok)
+	}
+	for _, route := range dashboardRoutes {
+		group.Add(route.Method, route.Path, ok)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		status int
+	}{
+		{"public node route", http.MethodGet, "/dashboard/api/core/v2/info", http.StatusOK},
+		{"public dashboard route", http.MethodGet, "/dashboard/api/conflicts?blockId=0x1234", http.StatusOK},
+		{"protected node route", http.MethodGet, "/dashboard/api/spammer/v1/status", http.StatusUnauthorized},
+		{"protected dashboard route", http.MethodGet, "/dashboard/api/peers/history", http.StatusUnauthorized},
+		{"protected route with public path in query string", http.MethodGet, "/dashboard/api/spammer/v1/status?x=/dashboard/api/core/v2/info", http.StatusUnauthorized},
+		{"protected route with public dashboard path in query string", http.MethodGet, "/dashboard/api/peers/history?x=/api/conflicts", http.StatusUnauthorized},
+		{"public path with protected method", http.MethodPost, "/dashboard/api/conflicts", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			req.Header.Set(echo.HeaderAuthorization, "Bearer invalid")
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, rec.Code)
+			}
+		})
+	}
+}
@@ -2,12 +2,15 @@ package dashboard
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
 )
 
 const (
@@ -19,6 +22,7 @@ const (
 
 	BasePath              = ""
 	APIBasePath           = "/api"
+	DashboardAPIPath      = "/dashboard" + APIBasePath
 	CoreAPIRoute          = BasePath + "/" + FeatureCoreAPI
 	DashboardMetricsRoute = APIBasePath + "/" + FeatureDashboardMetrics
 	IndexerRoute          = BasePath + "/" + FeatureIndexer
@@ -189,6 +193,107 @@ const (
 	RouteParticipationAdminDeleteEvent = ParticipationRoute + "/admin/events/:" + ParameterParticipationEventID
 )
 
+const (
+	// RoutePeersHistory is the route to get the connectivity history of the peers tracked by the dashboard.
+	// GET returns the connectivity events, uptime and gossip throughput of all peers.
+	RoutePeersHistory = BasePath + "/peers/history"
+
+	// RouteVisualizerSnapshot is the route to export the vertices currently held by the visualizer.
+	// GET returns the snapshot in the format given by the "format" query parameter (json, dot, graphml).
+	RouteVisualizerSnapshot = BasePath + "/visualizer/snapshot"
+
+	// RouteConflicts is the route to search the conflicting blocks seen in the confirmed milestone cones.
+	// GET returns the conflicts matching the optional "blockId", "transactionId", "milestoneIndex" and "reason" query parameters.
+	RouteConflicts = BasePath + "/conflicts"
+
+	// RouteSearch is the route to resolve any identifier (block, transaction, output, milestone, address, alias, NFT or foundry).
+	// GET returns the kind of the identifier and the resource returned by the node.
+	RouteSearch = BasePath + "/search/:" + ParameterSearchQuery
+
+	// RouteAddressBalance is the route to get the aggregated balance of an address.
+	// GET returns the base token and native token balances, the storage deposit return obligations and the IDs of all basic, alias and NFT outputs owned by the address.
+	RouteAddressBalance = BasePath + "/addresses/:" + ParameterBech32Address
+
+	// RouteBlockView is the route to get a decoded, human-oriented view of a block.
+	// GET returns the payload of the block with resolved transaction inputs, explained outputs and milestone details.
+	RouteBlockView = BasePath + "/blocks/:" + ParameterBlockID + "/decoded"
+
+	// RouteTransactionView is the route to get the decoded view of the block which included a transaction.
+	// GET returns the same view as RouteBlockView.
+	RouteTransactionView = BasePath + "/transactions/:" + ParameterTransactionID + "/decoded"
+
+	// RouteOpenAPI is the route to get the OpenAPI specification of the dashboard.
+	// GET returns the OpenAPI 3 document describing the auth route, the websocket topics, the dashboard routes and the forwarded node API routes.
+	RouteOpenAPI = BasePath + "/openapi.json"
+)
+
+// dashboardRoute is a route served by the dashboard itself.
+type dashboardRoute struct {
+	Method  string
+	Path    string
+	Summary string
+	// QueryParameters are the optional query parameters of the route.
+	QueryParameters []string
+	// Response is a value of the type returned by the route, it is used to describe the response.
+	Response any
+	Handler  func(d *Dashboard, c echo.Context) error
+}
+
+// dashboardRoutes are the routes served by the dashboard itself.
+var dashboardRoutes = []*dashboardRoute{
+	{
+		Method:   http.MethodGet,
+		Path:     RoutePeersHistory,
+		Summary:  "Returns the connectivity events, uptime and gossip throughput of all peers.",
+		Response: []*PeerHistoryEntry{},
+		Handler:  (*Dashboard).peersHistoryRoute,
+	},
+	{
+		Method:          http.MethodGet,
+		Path:            RouteVisualizerSnapshot,
+		Summary:         "Exports the vertices currently held by the visualizer (json, dot, graphml).",
+		QueryParameters: []string{QueryParameterFormat},
+		Response:        &VisualizerSnapshot{},
+		Handler:         (*Dashboard).visualizerSnapshotRoute,
+	},
+	{
+		Method:          http.MethodGet,
+		Path:            RouteConflicts,
+		Summary:         "Searches the conflicting blocks seen in the confirmed milestone cones.",
+		QueryParameters: []string{QueryParameterBlockID, QueryParameterTransactionID, QueryParameterMilestoneIndex, QueryParameterReason},
+		Response:        []*ConflictingBlock{},
+		Handler:         (*Dashboard).conflictsRoute,
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     RouteSearch,
+		Summary:  "Resolves any identifier (block, transaction, output, milestone, address, alias, NFT or foundry).",
+		Response: &SearchResult{},
+		Handler:  (*Dashboard).searchRoute,
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     RouteAddressBalance,
+		Summary:  "Returns the aggregated balance of all outputs owned by an address.",
+		Response: &AddressBalance{},
+		Handler:  (*Dashboard).addressBalanceRoute,
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     RouteBlockView,
+		Summary:  "Returns a decoded, human-oriented view of a block.",
+		Response: &BlockView{},
+		Handler:  (*Dashboard).blockViewRoute,
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     RouteTransactionView,
+		Summary:  "Returns the decoded view of the block which included a transaction.",
+		Response: &BlockView{},
+		Handler:  (*Dashboard).transactionViewRoute,
+	},
+}
+
 const (
 	// RouteSpammerStatus is the route to get the status of the spammer.
 	// GET the current status of the spammer.
@@ -205,166 +310,148 @@ const (
 
 func (d *Dashboard) setupAPIRoutes(routeGroup *echo.Group) {
 
-	routeGroup.GET(RouteRoutes, func(c echo.Context) error {
-		return d.forwardRequest(c)
-	})
-
-	routeGroup.GET(RouteCoreInfo, func(c echo.Context) error {
-		return d.forwardRequest(c)
-	})
-
-	routeGroup.GET(RouteCoreBlockMetadata, func(c echo.Context) error {
-		return d.forwardRequest(c)
-	})
-
-	routeGroup.GET(RouteCoreBlock, func(c echo.Context) error {
-		return d.forwardRequest(c)
-	})
-
-	routeGroup.GET(RouteCoreTransactionsIncludedBlock, func(c echo.Context) error {
-		return d.forwardRequest(c)
-	})
-
-	routeGroup.GET(RouteCoreMilestoneByID, func(c echo.Context) error {
-		return d.forwardRequest(c)
-	})
-
-	routeGroup.GET(RouteCoreMilestoneByIndex, func(c echo.Context) error {
-		return d.forwardRequest(c)
-	})
-
-	routeGroup.GET(RouteCoreOutput, func(c echo.Context) error {
-		return d.forwardRequest(c)
-	})
-
-	routeGroup.DELETE(RouteCorePeer, func(c echo.Context) error {
-		return d.forwardRequest(c)
-	})
-
-	routeGroup.POST(RouteCorePeers, func(c echo.Context) error {
-		return d.forwardRequest(c)
-	})
-
-	// indexer
-	routeGroup.GET(RouteIndexerOutputsBasic, func(c echo.Context) error {
-		return d.forwardRequest(c)
-	})
-	routeGroup.GET(RouteIndexerOutputsAliases, func(c echo.Context) error {
-		return d.forwardRequest(c)
-	})
-	routeGroup.GET(RouteIndexerOutputsAliasByID, func(c echo.Context) error {
-		return d.forwardRequest(c)
-	})
-	routeGroup.GET(RouteIndexerOutputsNFTs, func(c echo.Context) error {
-		return d.forwardRequest(c)
-	})
-	routeGroup.GET(RouteIndexerOutputsNFTByID, func(c echo.Context) error {
-		return d.forwardRequest(c)
-	})
-	routeGroup.GET(RouteIndexerOutputsFoundries, func(c echo.Context) error {
-		return d.forwardRequest(c)
-	})
-	routeGroup.GET(RouteIndexerOutputsFoundryByID, func(c echo.Context) error {
-		return d.forwardRequest(c)
-	})
-
-	// participation
-	routeGroup.GET(RouteParticipationEvents, func(c echo.Context) error {
-		return d.forwardRequest(c)
-	})
-
-	routeGroup.GET(RouteParticipationEvent, func(c echo.Context) error {
-		return d.forwardRequest(c)
-	})
-
-	routeGroup.GET(RouteParticipationEventStatus, func(c echo.Context) error {
-		return d.forwardRequest(c)
-	})
-
-	routeGroup.POST(RouteParticipationAdminCreateEvent, func(c echo.Context) error {
-		return d.forwardRequest(c)
-	})
-
-	routeGroup.DELETE(RouteParticipationAdminDeleteEvent, func(c echo.Context) error {
-		return d.forwardRequest(c)
-	})
-
-	// spammmer
-	routeGroup.GET(RouteSpammerStatus, func(c echo.Context) error {
-		return d.forwardRequest(c)
-	})
-
-	routeGroup.POST(RouteSpammerStart, func(c echo.Context) error {
-		return d.forwardRequest(c)
-	})
-
-	routeGroup.POST(RouteSpammerStop, func(c echo.Context) error {
-		return d.forwardRequest(c)
-	})
-}
+	// node API
+	for _, route := range d.routeRegistry.Routes() {
+		routeGroup.Add(route.Method, route.Path, d.proxyRouteHandler(route))
+	}
 
-func readAndCloseRequestBody(res *http.Request) ([]byte, error) {
-	defer res.Body.Close()
-	resBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, fmt.Errorf("unable to read response body: %w", err)
+	// dashboard
+	for _, route := range dashboardRoutes {
+		handler := route.Handler
+		routeGroup.Add(route.Method, route.Path, func(c echo.Context) error {
+			return handler(d, c)
+		})
 	}
 
-	return resBody, nil
+	// the specification describes itself, it can't be part of the dashboard routes
+	routeGroup.GET(RouteOpenAPI, d.openAPIRoute)
 }
 
-func readAndCloseResponseBody(res *http.Response) ([]byte, error) {
-	defer res.Body.Close()
-	resBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, fmt.Errorf("unable to read response body: %w", err)
-	}
+// proxiedRequestHeaders are the request headers passed through to the node.
+var proxiedRequestHeaders = []string{
+	echo.HeaderContentType,
+	echo.HeaderAccept,
+	echo.HeaderIfModifiedSince,
+	"If-None-Match",
+}
+
+// proxiedResponseHeaders are the response headers passed through to the client.
+var proxiedResponseHeaders = []string{
+	echo.HeaderContentType,
+	echo.HeaderLastModified,
+	"Cache-Control",
+	"ETag",
+}
+
+// errProxyResponseTooLarge is returned if the response of the node exceeds the maximum body size.
+var errProxyResponseTooLarge = errors.New("response body exceeds the limit")
 
-	return resBody, nil
+// forwardRequest forwards the request of the route to the node and the response back to the client.
+// The status, the relevant headers and binary bodies are preserved.
+func (d *Dashboard) forwardRequest(c echo.Context, proxyRoute *ProxyRoute) error {
+	return d.proxyRequest(c, proxyRoute, nil)
 }
 
-func (d *Dashboard) forwardRequest(c echo.Context) error {
+// proxyRequest forwards the request of the route to the node and streams the response to the client.
+// If onCacheable is given, successful responses are buffered as well and passed to it once they were completely sent.
+func (d *Dashboard) proxyRequest(c echo.Context, proxyRoute *ProxyRoute, onCacheable func(response *cachedResponse)) error {
 
-	reqBody, err := readAndCloseRequestBody(c.Request())
+	request := c.Request()
+
+	// construct the route on the node
+	route := strings.Replace(request.RequestURI, "/dashboard", "", 1)
+
+	var reqBody io.Reader
+	if request.Body != nil && request.Body != http.NoBody {
+		// limit the size of the request body, the node is not protected otherwise
+		reqBody = http.MaxBytesReader(c.Response(), request.Body, d.proxyMaxBodySize)
+	}
+
+	// make the request
+	res, ctxProxyCancel, err := d.sendProxyRequest(request, proxyRoute, route, reqBody)
 	if err != nil {
-		return err
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return echo.NewHTTPError(http.StatusRequestEntityTooLarge, fmt.Sprintf("request body exceeds the limit of %d bytes", d.proxyMaxBodySize))
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			return echo.NewHTTPError(http.StatusGatewayTimeout, "node did not respond in time")
+		}
+
+		return echo.NewHTTPError(http.StatusBadGateway, fmt.Sprintf("request to node failed: %s", err))
 	}
 
-	// construct request URL
-	url := fmt.Sprintf("%s%s", d.nodeClient.BaseURL, strings.Replace(c.Request().RequestURI, "/dashboard", "", 1))
+	defer ctxProxyCancel()
+	defer res.Body.Close()
+
+	if res.ContentLength > d.proxyMaxBodySize {
+		return echo.NewHTTPError(http.StatusBadGateway, fmt.Sprintf("response body exceeds the limit of %d bytes", d.proxyMaxBodySize))
+	}
 
-	// construct request
-	req, err := http.NewRequestWithContext(c.Request().Context(), c.Request().Method, url, func() io.Reader {
-		if reqBody == nil {
-			return nil
+	header := make(http.Header)
+	for _, key := range proxiedResponseHeaders {
+		if value := res.Header.Get(key); value != "" {
+			header.Set(key, value)
+			c.Response().Header().Set(key, value)
 		}
+	}
+	if res.ContentLength >= 0 {
+		c.Response().Header().Set(echo.HeaderContentLength, strconv.FormatInt(res.ContentLength, 10))
+	}
 
-		return bytes.NewReader(reqBody)
-	}())
-	if err != nil {
-		return fmt.Errorf("unable to build http request: %w", err)
+	// only cacheable responses are buffered, all others are streamed to the client
+	var cacheBuffer *bytes.Buffer
+	var dst io.Writer = c.Response()
+	if onCacheable != nil && res.StatusCode == http.StatusOK {
+		c.Response().Header().Set(HeaderXCache, XCacheMiss)
+
+		cacheBuffer = new(bytes.Buffer)
+		dst = io.MultiWriter(c.Response(), cacheBuffer)
 	}
+	c.Response().WriteHeader(res.StatusCode)
+
+	if _, err := io.Copy(dst, &proxyBodyReader{reader: res.Body, remaining: d.proxyMaxBodySize}); err != nil {
+		if errors.Is(err, errProxyResponseTooLarge) {
+			d.LogWarnf("response of %s exceeds the limit of %d bytes", request.RequestURI, d.proxyMaxBodySize)
+		} else {
+			d.LogDebugf("failed to stream response of %s: %s", request.RequestURI, err)
+		}
 
-	if c.Request().URL.User != nil {
-		// set the userInfo for basic auth
-		req.URL.User = c.Request().URL.User
+		// the status was already sent, the connection is aborted so the client doesn't take the truncated body as complete
+		panic(http.ErrAbortHandler)
 	}
 
-	contentType := c.Request().Header.Get(echo.HeaderContentType)
-	if contentType != "" {
-		req.Header.Set(echo.HeaderContentType, contentType)
+	if cacheBuffer != nil {
+		onCacheable(&cachedResponse{
+			Header: header,
+			Body:   cacheBuffer.Bytes(),
+		})
 	}
 
-	// make the request
-	res, err := d.nodeClient.HTTPClient().Do(req)
-	if err != nil {
-		return err
+	return nil
+}
+
+// proxyBodyReader reads the body of a response of the node
+// and fails with errProxyResponseTooLarge once it exceeds the maximum body size.
+type proxyBodyReader struct {
+	reader    io.Reader
+	remaining int64
+}
+
+func (r *proxyBodyReader) Read(p []byte) (int, error) {
+	// read one byte more than allowed to detect bodies of unknown length that exceed the limit
+	if int64(len(p)) > r.remaining+1 {
+		p = p[:r.remaining+1]
 	}
 
-	resBody, err := readAndCloseResponseBody(res)
-	if err != nil {
-		return err
+	n, err := r.reader.Read(p)
+	if int64(n) > r.remaining {
+		n = int(r.remaining)
+		r.remaining = 0
+
+		return n, errProxyResponseTooLarge
 	}
+	r.remaining -= int64(n)
 
-	return c.JSONBlob(res.StatusCode, resBody)
+	return n, err
 }
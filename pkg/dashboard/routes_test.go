@@ -0,0 +1,145 @@
+package dashboard
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/inx-dashboard/pkg/upstream"
+	"github.com/iotaledger/iota.go/v3/nodeclient"
+)
+
+func TestProxyRequestStreamsResponses(t *testing.T) {
+	const maxBodySize = 16
+
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := strings.Repeat("a", len(strings.TrimPrefix(r.URL.Path, "/api/core/v2/blocks/")))
+		if r.URL.Query().Has("chunked") {
+			// the length of the body is not known in advance
+			w.Header().Set(echo.HeaderContentType, echo.MIMETextPlain)
+			w.(http.Flusher).Flush()
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer node.Close()
+
+	d := &Dashboard{
+		WrappedLogger:    logger.NewWrappedLogger(nil),
+		upstreams:        upstream.NewPool(UpstreamPrimaryName, nodeclient.New(node.URL), 1, time.Minute, nil),
+		tracer:           noop.NewTracerProvider().Tracer(tracerName),
+		proxyTimeout:     time.Second,
+		proxyMaxBodySize: maxBodySize,
+	}
+
+	tests := []struct {
+		name       string
+		target     string
+		cacheable  bool
+		wantStatus int
+		wantBody   string
+		wantAbort  bool
+		wantCached bool
+	}{
+		{
+			name:       "within the limit",
+			target:     "/dashboard/api/core/v2/blocks/0123456789",
+			wantStatus: http.StatusOK,
+			wantBody:   "aaaaaaaaaa",
+		},
+		{
+			name:       "exactly the limit of unknown length",
+			target:     "/dashboard/api/core/v2/blocks/0123456789abcdef?chunked",
+			wantStatus: http.StatusOK,
+			wantBody:   strings.Repeat("a", maxBodySize),
+		},
+		{
+			name:       "cacheable",
+			target:     "/dashboard/api/core/v2/blocks/0123",
+			cacheable:  true,
+			wantStatus: http.StatusOK,
+			wantBody:   "aaaa",
+			wantCached: true,
+		},
+		{
+			name:       "known length above the limit",
+			target:     "/dashboard/api/core/v2/blocks/0123456789abcdef0",
+			wantStatus: http.StatusBadGateway,
+		},
+		{
+			name:      "unknown length above the limit",
+			target:    "/dashboard/api/core/v2/blocks/0123456789abcdef0?chunked",
+			cacheable: true,
+			wantAbort: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, tt.target, nil), rec)
+
+			var cached *cachedResponse
+			var onCacheable func(response *cachedResponse)
+			if tt.cacheable {
+				onCacheable = func(response *cachedResponse) {
+					cached = response
+				}
+			}
+
+			var err error
+			aborted := func() (aborted bool) {
+				defer func() {
+					if r := recover(); r != nil {
+						if r != http.ErrAbortHandler {
+							panic(r)
+						}
+						aborted = true
+					}
+				}()
+				err = d.proxyRequest(c, &ProxyRoute{Method: http.MethodGet, Path: RouteCoreBlock, Auth: RouteAuthPublic}, onCacheable)
+
+				return false
+			}()
+
+			if aborted != tt.wantAbort {
+				t.Fatalf("expected aborted %v, got %v", tt.wantAbort, aborted)
+			}
+			if aborted {
+				if cached != nil {
+					t.Error("expected the truncated response not to be cached")
+				}
+
+				return
+			}
+
+			status := rec.Code
+			var httpErr *echo.HTTPError
+			if errors.As(err, &httpErr) {
+				status = httpErr.Code
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if status != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, status)
+			}
+			if err == nil && rec.Body.String() != tt.wantBody {
+				t.Errorf("expected body %q, got %q", tt.wantBody, rec.Body.String())
+			}
+
+			if (cached != nil) != tt.wantCached {
+				t.Fatalf("expected cached %v, got %v", tt.wantCached, cached != nil)
+			}
+			if cached != nil && string(cached.Body) != tt.wantBody {
+				t.Errorf("expected cached body %q, got %q", tt.wantBody, cached.Body)
+			}
+		})
+	}
+}
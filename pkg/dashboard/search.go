@@ -0,0 +1,165 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/inx-dashboard/pkg/common"
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/iota.go/v3/nodeclient"
+)
+
+const (
+	// ParameterSearchQuery is used to pass the identifier to search for.
+	ParameterSearchQuery = "query"
+
+	SearchResultKindBlock       = "block"
+	SearchResultKindTransaction = "transaction"
+	SearchResultKindMilestone   = "milestone"
+	SearchResultKindOutput      = "output"
+	SearchResultKindAddress     = "address"
+	SearchResultKindAlias       = "alias"
+	SearchResultKindNFT         = "nft"
+	SearchResultKindFoundry     = "foundry"
+)
+
+// SearchResult is the node API resource an identifier was resolved to.
+type SearchResult struct {
+	Query string `json:"query"`
+	Kind  string `json:"kind"`
+	// Result is the response of the node API route the identifier was resolved with.
+	Result json.RawMessage `json:"result"`
+}
+
+// searchCandidate is a node API route that may resolve the identifier.
+type searchCandidate struct {
+	kind    string
+	feature string
+	// route is the node API route relative to the API base path, including the query string.
+	route string
+}
+
+// nodeAPIRoute replaces the named parameter of the route with the given value.
+func nodeAPIRoute(route string, parameter string, value string) string {
+	return strings.Replace(route, ":"+parameter, url.PathEscape(value), 1)
+}
+
+// searchCandidates returns the node API routes that may resolve the query, in the order they should be tried.
+// Block, transaction, milestone, alias and NFT IDs share the same length, so all of them are tried.
+func searchCandidates(query string) ([]*searchCandidate, error) {
+	if index, err := strconv.ParseUint(query, 10, 32); err == nil {
+		return []*searchCandidate{
+			{kind: SearchResultKindMilestone, feature: FeatureCoreAPI, route: nodeAPIRoute(RouteCoreMilestoneByIndex, ParameterMilestoneIndex, strconv.FormatUint(index, 10))},
+		}, nil
+	}
+
+	if strings.HasPrefix(query, "0x") {
+		data, err := iotago.DecodeHex(query)
+		if err != nil {
+			return nil, errors.WithMessagef(common.ErrInvalidParameter, "invalid hex identifier: %s", query)
+		}
+
+		switch len(data) {
+		case iotago.BlockIDLength:
+			return []*searchCandidate{
+				{kind: SearchResultKindBlock, feature: FeatureCoreAPI, route: nodeAPIRoute(RouteCoreBlock, ParameterBlockID, query)},
+				{kind: SearchResultKindTransaction, feature: FeatureCoreAPI, route: nodeAPIRoute(RouteCoreTransactionsIncludedBlock, ParameterTransactionID, query)},
+				{kind: SearchResultKindMilestone, feature: FeatureCoreAPI, route: nodeAPIRoute(RouteCoreMilestoneByID, ParameterMilestoneID, query)},
+				{kind: SearchResultKindAlias, feature: FeatureIndexer, route: nodeAPIRoute(RouteIndexerOutputsAliasByID, ParameterAliasID, query)},
+				{kind: SearchResultKindNFT, feature: FeatureIndexer, route: nodeAPIRoute(RouteIndexerOutputsNFTByID, ParameterNFTID, query)},
+			}, nil
+
+		case iotago.OutputIDLength:
+			return []*searchCandidate{
+				{kind: SearchResultKindOutput, feature: FeatureCoreAPI, route: nodeAPIRoute(RouteCoreOutput, ParameterOutputID, query)},
+			}, nil
+
+		case iotago.FoundryIDLength:
+			return []*searchCandidate{
+				{kind: SearchResultKindFoundry, feature: FeatureIndexer, route: nodeAPIRoute(RouteIndexerOutputsFoundryByID, ParameterFoundryID, query)},
+			}, nil
+
+		default:
+			return nil, errors.WithMessagef(common.ErrInvalidParameter, "unknown identifier length: %d bytes", len(data))
+		}
+	}
+
+	_, address, err := iotago.ParseBech32(query)
+	if err != nil {
+		return nil, errors.WithMessagef(common.ErrInvalidParameter, "unknown identifier: %s", query)
+	}
+
+	switch addr := address.(type) {
+	case *iotago.AliasAddress:
+		return []*searchCandidate{
+			{kind: SearchResultKindAlias, feature: FeatureIndexer, route: nodeAPIRoute(RouteIndexerOutputsAliasByID, ParameterAliasID, addr.AliasID().ToHex())},
+		}, nil
+
+	case *iotago.NFTAddress:
+		return []*searchCandidate{
+			{kind: SearchResultKindNFT, feature: FeatureIndexer, route: nodeAPIRoute(RouteIndexerOutputsNFTByID, ParameterNFTID, addr.NFTID().ToHex())},
+		}, nil
+
+	default:
+		return []*searchCandidate{
+			{kind: SearchResultKindAddress, feature: FeatureIndexer, route: RouteIndexerOutputsBasic + "?address=" + url.QueryEscape(query)},
+		}, nil
+	}
+}
+
+// search resolves the query by trying the candidate node API routes until one of them knows the identifier.
+func (d *Dashboard) search(ctx context.Context, query string) (*SearchResult, error) {
+	candidates, err := searchCandidates(strings.TrimSpace(query))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range candidates {
+		if !d.nodeFeatures.Available(candidate.feature) {
+			continue
+		}
+
+		ctxNode, ctxNodeCancel := context.WithTimeout(ctx, nodeTimeout)
+		result := json.RawMessage{}
+		//nolint:bodyclose // false positive, it is done in the client.Do method
+		_, err := d.nodeClient.Do(ctxNode, http.MethodGet, APIBasePath+candidate.route, nil, &result)
+		ctxNodeCancel()
+		if err != nil {
+			if errors.Is(err, nodeclient.ErrHTTPNotFound) || errors.Is(err, nodeclient.ErrHTTPBadRequest) {
+				// the identifier is not of this kind
+				continue
+			}
+
+			return nil, err
+		}
+
+		return &SearchResult{
+			Query:  query,
+			Kind:   candidate.kind,
+			Result: result,
+		}, nil
+	}
+
+	return nil, echo.NewHTTPError(http.StatusNotFound, "no resource found for the given identifier")
+}
+
+func (d *Dashboard) searchRoute(c echo.Context) error {
+	// the search fans out to the node, therefore it uses the (stricter) indexer budget
+	if err := d.checkRateLimit(c, &ProxyRoute{Feature: FeatureIndexer}); err != nil {
+		return err
+	}
+
+	result, err := d.search(c.Request().Context(), c.Param(ParameterSearchQuery))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,220 @@
+package dashboard
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/inx-dashboard/pkg/common"
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/iota.go/v3/nodeclient"
+)
+
+// httpErrorStatus returns the status code the error is reported with, or 0 if the error is not an HTTP error.
+func httpErrorStatus(err error) int {
+	var httpErr *echo.HTTPError
+	if !errors.As(err, &httpErr) {
+		return 0
+	}
+
+	return httpErr.Code
+}
+
+func TestSearchCandidates(t *testing.T) {
+	id32 := "0x" + strings.Repeat("ab", 32)
+	outputID := "0x" + strings.Repeat("cd", iotago.OutputIDLength)
+	foundryID := "0x" + strings.Repeat("ef", iotago.FoundryIDLength)
+
+	var aliasID iotago.AliasID
+	aliasID[0] = 1
+	var nftID iotago.NFTID
+	nftID[0] = 2
+	var ed25519Address iotago.Ed25519Address
+	ed25519Address[0] = 3
+	ed25519Bech32 := ed25519Address.Bech32(iotago.PrefixTestnet)
+
+	tests := []struct {
+		name       string
+		query      string
+		wantRoutes []string
+		wantKinds  []string
+		wantErr    bool
+	}{
+		{
+			name:       "milestone index",
+			query:      "1234",
+			wantKinds:  []string{SearchResultKindMilestone},
+			wantRoutes: []string{"/core/v2/milestones/by-index/1234"},
+		},
+		{
+			name:      "32 byte identifier",
+			query:     id32,
+			wantKinds: []string{SearchResultKindBlock, SearchResultKindTransaction, SearchResultKindMilestone, SearchResultKindAlias, SearchResultKindNFT},
+			wantRoutes: []string{
+				"/core/v2/blocks/" + id32,
+				"/core/v2/transactions/" + id32 + "/included-block",
+				"/core/v2/milestones/" + id32,
+				"/indexer/v1/outputs/alias/" + id32,
+				"/indexer/v1/outputs/nft/" + id32,
+			},
+		},
+		{
+			name:       "output ID",
+			query:      outputID,
+			wantKinds:  []string{SearchResultKindOutput},
+			wantRoutes: []string{"/core/v2/outputs/" + outputID},
+		},
+		{
+			name:       "foundry ID",
+			query:      foundryID,
+			wantKinds:  []string{SearchResultKindFoundry},
+			wantRoutes: []string{"/indexer/v1/outputs/foundry/" + foundryID},
+		},
+		{
+			name:       "ed25519 address",
+			query:      ed25519Bech32,
+			wantKinds:  []string{SearchResultKindAddress},
+			wantRoutes: []string{"/indexer/v1/outputs/basic?address=" + ed25519Bech32},
+		},
+		{
+			name:       "alias address",
+			query:      aliasID.ToAddress().Bech32(iotago.PrefixTestnet),
+			wantKinds:  []string{SearchResultKindAlias},
+			wantRoutes: []string{"/indexer/v1/outputs/alias/" + aliasID.ToHex()},
+		},
+		{
+			name:       "nft address",
+			query:      nftID.ToAddress().Bech32(iotago.PrefixTestnet),
+			wantKinds:  []string{SearchResultKindNFT},
+			wantRoutes: []string{"/indexer/v1/outputs/nft/" + nftID.ToHex()},
+		},
+		{
+			name:    "milestone index out of range",
+			query:   "4294967296",
+			wantErr: true,
+		},
+		{
+			name:    "invalid hex",
+			query:   "0xzz",
+			wantErr: true,
+		},
+		{
+			name:    "unknown identifier length",
+			query:   "0x" + strings.Repeat("ab", 16),
+			wantErr: true,
+		},
+		{
+			name:    "unknown identifier",
+			query:   "dashboard",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidates, err := searchCandidates(tt.query)
+			if tt.wantErr {
+				if !errors.Is(err, common.ErrInvalidParameter) {
+					t.Fatalf("expected an invalid parameter error, got %v", err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(candidates) != len(tt.wantKinds) {
+				t.Fatalf("expected %d candidates, got %d", len(tt.wantKinds), len(candidates))
+			}
+			for i, candidate := range candidates {
+				if candidate.kind != tt.wantKinds[i] {
+					t.Errorf("candidate %d: expected kind %s, got %s", i, tt.wantKinds[i], candidate.kind)
+				}
+				if candidate.route != tt.wantRoutes[i] {
+					t.Errorf("candidate %d: expected route %s, got %s", i, tt.wantRoutes[i], candidate.route)
+				}
+			}
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		switch {
+		case r.URL.Path == "/api/core/v2/milestones/by-index/10":
+			_, _ = w.Write([]byte(`{"index":10}`))
+		case strings.HasPrefix(r.URL.Path, "/api/indexer/"):
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"code":"400","message":"invalid address"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":"404","message":"not found"}}`))
+		}
+	}))
+	defer node.Close()
+
+	d := &Dashboard{
+		nodeClient:   nodeclient.New(node.URL),
+		nodeFeatures: NewNodeFeatures(),
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		wantKind   string
+		wantStatus int
+	}{
+		{
+			name:     "known milestone",
+			query:    "10",
+			wantKind: SearchResultKindMilestone,
+		},
+		{
+			name:       "unknown milestone",
+			query:      "11",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "unknown identifier",
+			query:      "0x" + strings.Repeat("ab", 32),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "address rejected by the indexer",
+			query:      (&iotago.Ed25519Address{1}).Bech32(iotago.PrefixMainnet),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "invalid identifier",
+			query:      "dashboard",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := d.search(context.Background(), tt.query)
+			if tt.wantStatus != 0 {
+				if status := httpErrorStatus(err); status != tt.wantStatus {
+					t.Fatalf("expected status %d, got %v", tt.wantStatus, err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result.Kind != tt.wantKind {
+				t.Errorf("expected kind %s, got %s", tt.wantKind, result.Kind)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package dashboard
+
+import (
+	"context"
+	"time"
+
+	"github.com/iotaledger/hive.go/runtime/timeutil"
+	"github.com/iotaledger/inx-dashboard/pkg/daemon"
+)
+
+const (
+	// OrphanThreshold is the time after which a block that was not referenced by a milestone is considered an orphan.
+	OrphanThreshold = 1 * time.Minute
+)
+
+// TangleAnalytics holds rolling statistics computed from the vertices in the visualizer window.
+type TangleAnalytics struct {
+	// Blocks is the amount of blocks in the visualizer window.
+	Blocks int `json:"blocks"`
+	// TipPoolSize is the amount of blocks that are not referenced by other blocks yet.
+	TipPoolSize int `json:"tipPoolSize"`
+	// AverageParents is the average amount of parents per block.
+	AverageParents float64 `json:"averageParents"`
+	// AverageTimeToSolidify is the average time in milliseconds between the arrival and the solidification of a block.
+	AverageTimeToSolidify float64 `json:"averageTimeToSolidify"`
+	// AverageTimeToReference is the average time in milliseconds between the arrival of a block and its reference by a milestone.
+	AverageTimeToReference float64 `json:"averageTimeToReference"`
+	// TransactionShare is the share of blocks with a transaction payload.
+	TransactionShare float64 `json:"transactionShare"`
+	// MilestoneShare is the share of blocks with a milestone payload.
+	MilestoneShare float64 `json:"milestoneShare"`
+	// TaggedDataShare is the share of blocks with a tagged data payload.
+	TaggedDataShare float64 `json:"taggedDataShare"`
+	// OrphanRate is the share of blocks older than the orphan threshold that were not referenced by a milestone.
+	OrphanRate float64 `json:"orphanRate"`
+}
+
+// Analytics computes the tangle analytics of the current visualizer window.
+func (v *Visualizer) Analytics() *TangleAnalytics {
+	return v.analytics(time.Now())
+}
+
+// analytics computes the tangle analytics of the current visualizer window at the given time.
+func (v *Visualizer) analytics(nowTime time.Time) *TangleAnalytics {
+	analytics := &TangleAnalytics{}
+
+	var parents, transactions, milestones, taggedData int
+	var solidified, referenced, orphanCandidates, orphans int
+	var timeToSolidify, timeToReference int64
+
+	now := nowTime.UnixMilli()
+
+	v.ForEachCreated(func(vertex *VisualizerVertex) bool {
+		analytics.Blocks++
+		parents += len(vertex.Parents)
+
+		if vertex.IsTip {
+			analytics.TipPoolSize++
+		}
+
+		switch vertex.PayloadKind {
+		case PayloadKindTransaction:
+			transactions++
+		case PayloadKindMilestone:
+			milestones++
+		case PayloadKindTaggedData:
+			taggedData++
+		}
+
+		if vertex.SolidTime != 0 && vertex.SolidTime >= vertex.ArrivalTime {
+			solidified++
+			timeToSolidify += vertex.SolidTime - vertex.ArrivalTime
+		}
+
+		if vertex.ReferencedTime != 0 && vertex.ReferencedTime >= vertex.ArrivalTime {
+			referenced++
+			timeToReference += vertex.ReferencedTime - vertex.ArrivalTime
+		}
+
+		if now-vertex.ArrivalTime > OrphanThreshold.Milliseconds() {
+			orphanCandidates++
+			if !vertex.IsReferenced {
+				orphans++
+			}
+		}
+
+		return true
+	})
+
+	if analytics.Blocks == 0 {
+		return analytics
+	}
+
+	blocks := float64(analytics.Blocks)
+	analytics.AverageParents = float64(parents) / blocks
+	analytics.TransactionShare = float64(transactions) / blocks
+	analytics.MilestoneShare = float64(milestones) / blocks
+	analytics.TaggedDataShare = float64(taggedData) / blocks
+
+	if solidified > 0 {
+		analytics.AverageTimeToSolidify = float64(timeToSolidify) / float64(solidified)
+	}
+	if referenced > 0 {
+		analytics.AverageTimeToReference = float64(timeToReference) / float64(referenced)
+	}
+	if orphanCandidates > 0 {
+		analytics.OrphanRate = float64(orphans) / float64(orphanCandidates)
+	}
+
+	return analytics
+}
+
+// TangleAnalytics returns the tangle analytics of the current visualizer window.
+func (d *Dashboard) TangleAnalytics() *TangleAnalytics {
+	return d.visualizer.Analytics()
+}
+
+func (d *Dashboard) runTangleAnalyticsFeed() {
+	if err := d.daemon.BackgroundWorker("Dashboard[TangleAnalytics]", func(ctx context.Context) {
+		ticker := timeutil.NewTicker(func() {
+			// skip if no client is subscribed
+			if !d.subscriptionManager.TopicHasSubscribers(MsgTypeTangleAnalytics) {
+				return
+			}
+
+			ctxMsg, ctxMsgCancel := context.WithTimeout(ctx, d.websocketWriteTimeout)
+			defer ctxMsgCancel()
+
+			_ = d.hub.BroadcastMsg(ctxMsg, &Msg{Type: MsgTypeTangleAnalytics, Data: d.TangleAnalytics()})
+		}, 5*time.Second, ctx)
+		ticker.WaitForGracefulShutdown()
+	}, daemon.PriorityStopDashboard); err != nil {
+		d.LogPanicf("failed to start worker: %s", err)
+	}
+}
@@ -0,0 +1,103 @@
+package dashboard
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVisualizerAnalytics(t *testing.T) {
+	now := time.UnixMilli(1_600_000_000_000)
+	nowMilli := now.UnixMilli()
+
+	// testVertex returns a created vertex with the given amount of parents that arrived the given time ago.
+	testVertex := func(index int, parents int, payloadKind string, age time.Duration) *VisualizerVertex {
+		vertex := newVertex(testBlockID(index))
+		vertex.isCreated = true
+		vertex.Parents = make([]string, parents)
+		vertex.PayloadKind = payloadKind
+		vertex.ArrivalTime = nowMilli - age.Milliseconds()
+
+		return vertex
+	}
+
+	tests := []struct {
+		name     string
+		vertices func() []*VisualizerVertex
+		want     TangleAnalytics
+	}{
+		{
+			name:     "empty window",
+			vertices: func() []*VisualizerVertex { return nil },
+			want:     TangleAnalytics{},
+		},
+		{
+			name: "mixed window",
+			vertices: func() []*VisualizerVertex {
+				// referenced transaction older than the orphan threshold
+				transaction := testVertex(1, 2, PayloadKindTransaction, 2*time.Minute)
+				transaction.IsTip = true
+				transaction.SolidTime = transaction.ArrivalTime + 100
+				transaction.IsReferenced = true
+				transaction.ReferencedTime = transaction.ArrivalTime + 1000
+
+				// orphaned milestone
+				milestone := testVertex(2, 1, PayloadKindMilestone, 90*time.Second)
+				milestone.SolidTime = milestone.ArrivalTime + 300
+
+				// recent tagged data that is not an orphan candidate yet
+				taggedData := testVertex(3, 3, PayloadKindTaggedData, time.Second)
+				taggedData.IsTip = true
+
+				// block that was solid before it arrived, the time to solidify is not counted
+				block := testVertex(4, 2, PayloadKindNone, 100*time.Second)
+				block.SolidTime = block.ArrivalTime - 50
+				block.IsReferenced = true
+				block.ReferencedTime = block.ArrivalTime + 2000
+
+				// only referenced, the block itself was not received
+				referencedOnly := newVertex(testBlockID(5))
+				referencedOnly.IsReferenced = true
+				referencedOnly.ReferencedTime = nowMilli
+
+				return []*VisualizerVertex{transaction, milestone, taggedData, block, referencedOnly}
+			},
+			want: TangleAnalytics{
+				Blocks:                 4,
+				TipPoolSize:            2,
+				AverageParents:         2,
+				AverageTimeToSolidify:  200,
+				AverageTimeToReference: 1500,
+				TransactionShare:       0.25,
+				MilestoneShare:         0.25,
+				TaggedDataShare:        0.25,
+				OrphanRate:             1.0 / 3,
+			},
+		},
+		{
+			name: "recent blocks only",
+			vertices: func() []*VisualizerVertex {
+				return []*VisualizerVertex{
+					testVertex(1, 1, PayloadKindNone, time.Second),
+					testVertex(2, 3, PayloadKindNone, OrphanThreshold),
+				}
+			},
+			want: TangleAnalytics{
+				Blocks:         2,
+				AverageParents: 2,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			visualizer := NewVisualizer(nil, nil, 10, 0)
+			for _, vertex := range tt.vertices() {
+				visualizer.vertices.Add(vertex.blockID, vertex)
+			}
+
+			if analytics := visualizer.analytics(now); *analytics != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, *analytics)
+			}
+		})
+	}
+}
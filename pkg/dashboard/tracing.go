@@ -0,0 +1,218 @@
+package dashboard
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/random"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/iotaledger/inx-dashboard/pkg/daemon"
+)
+
+const (
+	// TracingServiceName is the service name of the exported spans.
+	TracingServiceName = "inx-dashboard"
+
+	tracerName = "github.com/iotaledger/inx-dashboard/pkg/dashboard"
+
+	// attributeRequestID is the span attribute of the correlation ID.
+	attributeRequestID = attribute.Key("http.request.id")
+	// attributeUpstream is the span attribute of the node a request was sent to.
+	attributeUpstream = attribute.Key("upstream.name")
+)
+
+var (
+	// requestIDRegex matches the correlation IDs accepted from clients.
+	// Other values are replaced, they end up in the logs of the dashboard and the node.
+	requestIDRegex = regexp.MustCompile(`^[a-zA-Z0-9._:-]{1,128}$`)
+
+	// tracePropagator propagates the trace context of the client to the node.
+	tracePropagator = propagation.TraceContext{}
+)
+
+// requestIDMiddleware tags every request with a correlation ID.
+// The ID of the client is kept if it is valid, otherwise a new one is generated.
+// The ID is sent back to the client and forwarded to the node.
+func requestIDMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			requestID := c.Request().Header.Get(echo.HeaderXRequestID)
+			if !requestIDRegex.MatchString(requestID) {
+				requestID = random.String(32)
+				c.Request().Header.Set(echo.HeaderXRequestID, requestID)
+			}
+			c.Response().Header().Set(echo.HeaderXRequestID, requestID)
+
+			return next(c)
+		}
+	}
+}
+
+// initTracing sets up the tracer, the spans are only exported if tracing is enabled.
+func (d *Dashboard) initTracing() {
+	d.tracer = noop.NewTracerProvider().Tracer(tracerName)
+
+	if !d.tracingEnabled {
+		return
+	}
+
+	if d.tracingSampleRatio < 0 || d.tracingSampleRatio > 1 {
+		d.LogErrorfAndExit("tracing sample ratio must be between 0 and 1: %f", d.tracingSampleRatio)
+	}
+
+	// the exporter connects lazily, an unavailable collector doesn't prevent the startup
+	exporter, err := otlptracehttp.New(context.Background(),
+		otlptracehttp.WithEndpoint(d.tracingCollectorEndpoint),
+		otlptracehttp.WithInsecure(),
+	)
+	if err != nil {
+		d.LogErrorfAndExit("failed to create the span exporter: %s", err)
+	}
+
+	d.tracerProvider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceName(TracingServiceName),
+			semconv.ServiceVersion(d.appVersion),
+		)),
+		// follow the sampling decision of the client if its trace is continued
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(d.tracingSampleRatio))),
+	)
+	d.tracer = d.tracerProvider.Tracer(tracerName)
+}
+
+// startRequestSpan starts the span of a proxied route.
+// The trace of the client is only continued on protected routes, anyone can call public routes
+// and would otherwise decide which of their requests are exported. On public routes it is linked instead.
+func (d *Dashboard) startRequestSpan(c echo.Context, route *ProxyRoute) (context.Context, trace.Span) {
+	request := c.Request()
+
+	ctx := request.Context()
+	clientCtx := tracePropagator.Extract(ctx, propagation.HeaderCarrier(request.Header))
+
+	opts := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(request.Method),
+			semconv.HTTPRoute(c.Path()),
+			semconv.URLPath(request.URL.Path),
+			attributeRequestID.String(request.Header.Get(echo.HeaderXRequestID)),
+		),
+	}
+
+	if route.Auth == RouteAuthPublic {
+		opts = append(opts, trace.WithNewRoot(), trace.WithLinks(trace.LinkFromContext(clientCtx)))
+	} else {
+		ctx = clientCtx
+	}
+
+	return d.tracer.Start(ctx, request.Method+" "+c.Path(), opts...)
+}
+
+// endRequestSpan records the outcome of a proxied route.
+// The error handler didn't run yet, so the status of HTTP errors is taken from the error.
+func endRequestSpan(span trace.Span, c echo.Context, err error) {
+	status := c.Response().Status
+	if err != nil {
+		status = http.StatusInternalServerError
+
+		var httpErr *echo.HTTPError
+		if errors.As(err, &httpErr) {
+			status = httpErr.Code
+		}
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+	if status >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(status))
+	}
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+}
+
+// startUpstreamSpan starts the span of a request to a node.
+// The trace context is only propagated if the node is trusted with it.
+func (d *Dashboard) startUpstreamSpan(req *http.Request, upstreamName string, requestID string, propagate bool) trace.Span {
+	ctx, span := d.tracer.Start(req.Context(), "upstream "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.URLPath(req.URL.Path),
+			attributeUpstream.String(upstreamName),
+			attributeRequestID.String(requestID),
+		),
+	)
+	if propagate {
+		tracePropagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+	}
+
+	return span
+}
+
+// endUpstreamSpan records the outcome of a request to a node and logs it.
+// Requests are logged if tracing is enabled, to find them by their correlation ID.
+func (d *Dashboard) endUpstreamSpan(span trace.Span, req *http.Request, upstreamName string, requestID string, res *http.Response, err error, latency time.Duration) {
+	defer span.End()
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		switch {
+		case d.tracingEnabled:
+			d.LogInfof("request %s: %s %s%s failed after %v: %s", requestID, req.Method, upstreamName, req.URL.RequestURI(), latency.Truncate(time.Millisecond), err)
+		case d.debugLogRequests:
+			d.LogDebugf("request %s: %s %s%s failed after %v: %s", requestID, req.Method, upstreamName, req.URL.RequestURI(), latency.Truncate(time.Millisecond), err)
+		}
+
+		return
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(res.StatusCode))
+	if res.StatusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(res.StatusCode))
+	}
+
+	switch {
+	case d.tracingEnabled:
+		d.LogInfof("request %s: %s %s%s returned %d after %v", requestID, req.Method, upstreamName, req.URL.RequestURI(), res.StatusCode, latency.Truncate(time.Millisecond))
+	case d.debugLogRequests:
+		d.LogDebugf("request %s: %s %s%s returned %d after %v", requestID, req.Method, upstreamName, req.URL.RequestURI(), res.StatusCode, latency.Truncate(time.Millisecond))
+	}
+}
+
+func (d *Dashboard) runTracing() {
+	if d.tracerProvider == nil {
+		return
+	}
+
+	if err := d.daemon.BackgroundWorker("Dashboard[Tracing]", func(ctx context.Context) {
+		<-ctx.Done()
+
+		shutdownCtx, shutdownCtxCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer shutdownCtxCancel()
+
+		// export the remaining spans
+		//nolint:contextcheck // false positive
+		if err := d.tracerProvider.Shutdown(shutdownCtx); err != nil {
+			d.LogWarnf("failed to export the remaining spans: %s", err)
+		}
+	}, daemon.PriorityStopDashboard); err != nil {
+		d.LogPanicf("failed to start worker: %s", err)
+	}
+}
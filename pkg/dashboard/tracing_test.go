@@ -0,0 +1,43 @@
+package dashboard
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestStartRequestSpanIgnoresSamplingOfPublicClients(t *testing.T) {
+	// the dashboard itself never samples, only a continued trace of a client is sampled
+	d := &Dashboard{
+		tracer: sdktrace.NewTracerProvider(
+			sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(0))),
+		).Tracer(tracerName),
+	}
+
+	tests := []struct {
+		name    string
+		auth    string
+		sampled bool
+	}{
+		{"public route", RouteAuthPublic, false},
+		{"protected route", RouteAuthProtected, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/dashboard/api/core/v2/info", nil)
+			req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+
+			_, span := d.startRequestSpan(c, &ProxyRoute{Method: http.MethodGet, Path: RouteCoreInfo, Auth: tt.auth})
+			defer span.End()
+
+			if sampled := span.SpanContext().IsSampled(); sampled != tt.sampled {
+				t.Errorf("expected sampled %v, got %v", tt.sampled, sampled)
+			}
+		})
+	}
+}
@@ -1,5 +1,9 @@
 package dashboard
 
+import (
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
 // Msg represents a websocket message.
 type Msg struct {
 	Type WebSocketMsgType `json:"type"`
@@ -21,6 +25,8 @@ type NodeInfoExtended struct {
 	NodeID        string `json:"nodeId"`
 	NodeAlias     string `json:"nodeAlias"`
 	MemoryUsage   int64  `json:"memUsage"`
+	// Upstream is the name of the node that answered, which is always the INX connected node.
+	Upstream string `json:"upstream"`
 }
 
 // SyncStatus represents the node sync status.
@@ -40,6 +46,24 @@ type GossipMetrics struct {
 type Milestone struct {
 	MilestoneID string `json:"milestoneId"`
 	Index       uint32 `json:"index"`
+	Timestamp   uint32 `json:"timestamp"`
+}
+
+// MilestoneDetails represents the details of a confirmed milestone.
+type MilestoneDetails struct {
+	MilestoneID string `json:"milestoneId"`
+	Index       uint32 `json:"index"`
+	// Timestamp is the unix timestamp of the milestone payload.
+	Timestamp uint32 `json:"timestamp"`
+	// ReferencedBlocks is the number of blocks referenced by the milestone.
+	ReferencedBlocks int `json:"referencedBlocks"`
+	// IncludedBlocks is the number of referenced blocks with transactions that were included in the ledger.
+	IncludedBlocks int `json:"includedBlocks"`
+	// ConflictingBlocks is the number of referenced blocks with conflicting transactions.
+	ConflictingBlocks int `json:"conflictingBlocks"`
+	// ConfirmationLatency is the wall-clock delay in milliseconds between
+	// the milestone becoming the latest milestone and being confirmed.
+	ConfirmationLatency int64 `json:"confirmationLatency"`
 }
 
 // DatabaseSizesMetric represents database size metrics.
@@ -51,6 +75,10 @@ type DatabaseSizesMetric struct {
 }
 
 // VisualizerVertex defines a vertex in a DAG.
+// ArrivalTime, SolidTime and ReferencedTime are unix timestamps in milliseconds
+// of the arrival, the solidification and the milestone reference of the block.
+// Tag is the hex encoded tag of the tagged data payload, if the block or its transaction contains one.
+// Parents only holds the shortened IDs sent to the clients, the full IDs are kept internally to trace the cones.
 type VisualizerVertex struct {
 	ID                   string   `json:"id"`
 	Parents              []string `json:"parents"`
@@ -60,7 +88,14 @@ type VisualizerVertex struct {
 	IsTransaction        bool     `json:"isTransaction"`
 	IsMilestone          bool     `json:"isMilestone"`
 	IsTip                bool     `json:"isTip"`
+	PayloadKind          string   `json:"payloadKind"`
+	Tag                  string   `json:"tag,omitempty"`
+	ArrivalTime          int64    `json:"arrivalTime,omitempty"`
+	SolidTime            int64    `json:"solidTime,omitempty"`
+	ReferencedTime       int64    `json:"referencedTime,omitempty"`
+	blockID              iotago.BlockID
 	shortID              string
+	parents              iotago.BlockIDs
 	isCreated            bool
 	isReferencedByOthers bool
 }
@@ -81,3 +116,30 @@ type VisualizerConfirmationInfo struct {
 	IDs         []string `json:"ids"`
 	ExcludedIDs []string `json:"excludedIds"`
 }
+
+// VisualizerReplayConfirmationInfo signals the confirmation of a replayed milestone.
+type VisualizerReplayConfirmationInfo struct {
+	Index       uint32   `json:"index"`
+	MilestoneID string   `json:"milestoneId"`
+	Timestamp   uint32   `json:"timestamp"`
+	IDs         []string `json:"ids"`
+	ExcludedIDs []string `json:"excludedIds"`
+}
+
+// VisualizerReplayStatus signals the progress of a visualizer replay.
+type VisualizerReplayStatus struct {
+	StartIndex   uint32 `json:"startIndex"`
+	EndIndex     uint32 `json:"endIndex"`
+	CurrentIndex uint32 `json:"currentIndex"`
+	Finished     bool   `json:"finished"`
+	Error        string `json:"error,omitempty"`
+}
+
+// VisualizerHighlight holds the past and future cone of a block that should be highlighted in the visualizer.
+type VisualizerHighlight struct {
+	ID         string   `json:"id"`
+	PastCone   []string `json:"pastCone"`
+	FutureCone []string `json:"futureCone"`
+	// Truncated signals that the past cone was not fully traced because the limit of node lookups was reached.
+	Truncated bool `json:"truncated"`
+}
@@ -0,0 +1,169 @@
+package dashboard
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/runtime/timeutil"
+	"github.com/iotaledger/inx-dashboard/pkg/daemon"
+	"github.com/iotaledger/inx-dashboard/pkg/upstream"
+	"github.com/iotaledger/iota.go/v3/nodeclient"
+)
+
+const (
+	// UpstreamPrimaryName is the name of the INX connected node in the upstream pool.
+	UpstreamPrimaryName = "INX node"
+)
+
+// newUpstreamPool creates the pool of the INX connected node and the configured failover nodes.
+func (d *Dashboard) newUpstreamPool() *upstream.Pool {
+	pool := upstream.NewPool(UpstreamPrimaryName, d.nodeClient, d.proxyFailoverFailureThreshold, d.proxyFailoverOpenPeriod, func(endpoint *upstream.Endpoint, state upstream.State) {
+		if state == upstream.StateOpen {
+			d.LogWarnf("node %s failed, requests are sent to the failover nodes", endpoint.Name)
+
+			return
+		}
+		d.LogInfof("node %s circuit is %s", endpoint.Name, state)
+	})
+
+	for _, endpoint := range d.proxyFailoverEndpoints {
+		endpointURL, err := url.Parse(endpoint)
+		if err != nil || endpointURL.Scheme == "" || endpointURL.Host == "" {
+			d.LogErrorfAndExit("invalid failover node endpoint: %s", endpoint)
+		}
+
+		// credentials in the URL are used for basic auth, but never logged
+		pool.Add(endpointURL.Redacted(), nodeclient.New(endpoint))
+	}
+
+	return pool
+}
+
+// isUpstreamFailure returns whether the error of a node API request means that the node is unavailable.
+// Errors caused by the request itself (bad request, not found, ...) do not count.
+func isUpstreamFailure(err error) bool {
+	for _, clientErr := range []error{
+		nodeclient.ErrHTTPBadRequest,
+		nodeclient.ErrHTTPNotFound,
+		nodeclient.ErrHTTPUnauthorized,
+		nodeclient.ErrHTTPNotImplemented,
+		context.Canceled,
+	} {
+		if errors.Is(err, clientErr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isUpstreamFailureStatus returns whether the status of a proxied response means that the node is unavailable.
+func isUpstreamFailureStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// sendProxyRequest sends the request to the first available node that answers.
+// Only read-only requests of public routes fail over, all other requests are sent to the INX connected node.
+// The credentials, the correlation ID and the trace context of the client are only sent to the INX connected node.
+// The returned cancel function releases the context of the request and has to be called after the response was consumed.
+func (d *Dashboard) sendProxyRequest(request *http.Request, proxyRoute *ProxyRoute, route string, reqBody io.Reader) (*http.Response, context.CancelFunc, error) {
+	primary := d.upstreams.Primary()
+
+	endpoints := []*upstream.Endpoint{primary}
+	if proxyRoute.Auth == RouteAuthPublic && reqBody == nil && (request.Method == http.MethodGet || request.Method == http.MethodHead) {
+		endpoints = d.upstreams.Available()
+	}
+
+	requestID := request.Header.Get(echo.HeaderXRequestID)
+
+	var lastErr error
+	for i, endpoint := range endpoints {
+		ctxAttempt, ctxAttemptCancel := context.WithTimeout(request.Context(), d.proxyTimeout)
+
+		req, err := http.NewRequestWithContext(ctxAttempt, request.Method, endpoint.Client.BaseURL+route, reqBody)
+		if err != nil {
+			ctxAttemptCancel()
+
+			return nil, nil, errors.Wrap(err, "unable to build http request")
+		}
+
+		isPrimary := endpoint == primary
+		if isPrimary {
+			if request.URL.User != nil {
+				// set the userInfo for basic auth
+				req.URL.User = request.URL.User
+			}
+			req.Header.Set(echo.HeaderXRequestID, requestID)
+		}
+
+		for _, header := range proxiedRequestHeaders {
+			if value := request.Header.Get(header); value != "" {
+				req.Header.Set(header, value)
+			}
+		}
+
+		span := d.startUpstreamSpan(req, endpoint.Name, requestID, isPrimary)
+		start := time.Now()
+
+		res, err := endpoint.Client.HTTPClient().Do(req)
+		d.endUpstreamSpan(span, req, endpoint.Name, requestID, res, err, time.Since(start))
+		if err != nil {
+			ctxAttemptCancel()
+
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) || request.Context().Err() != nil {
+				// the client caused the error, not the node
+				return nil, nil, err
+			}
+
+			endpoint.ReportFailure()
+			lastErr = err
+
+			continue
+		}
+
+		if !isUpstreamFailureStatus(res.StatusCode) {
+			endpoint.ReportSuccess()
+
+			return res, ctxAttemptCancel, nil
+		}
+
+		endpoint.ReportFailure()
+		if i == len(endpoints)-1 {
+			// no node left, the response of the last one is passed to the client
+			return res, ctxAttemptCancel, nil
+		}
+
+		_ = res.Body.Close()
+		ctxAttemptCancel()
+	}
+
+	return nil, nil, lastErr
+}
+
+func (d *Dashboard) runUpstreamHealthCheck() {
+	if len(d.proxyFailoverEndpoints) == 0 {
+		// without failover nodes, there is nothing to switch to
+		return
+	}
+
+	if err := d.daemon.BackgroundWorker("Dashboard[UpstreamHealth]", func(ctx context.Context) {
+		ticker := timeutil.NewTicker(func() {
+			d.upstreams.CheckHealth(ctx, nodeTimeout)
+		}, d.proxyFailoverHealthCheckInterval, ctx)
+		ticker.WaitForGracefulShutdown()
+	}, daemon.PriorityStopDashboard); err != nil {
+		d.LogPanicf("failed to start worker: %s", err)
+	}
+}
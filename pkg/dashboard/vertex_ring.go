@@ -0,0 +1,89 @@
+package dashboard
+
+import (
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+// vertexRing stores a bounded amount of vertices in insertion order.
+// Insertion, lookup and eviction of the oldest vertex are O(1).
+// It is not safe for concurrent use.
+type vertexRing struct {
+	ids      []iotago.BlockID
+	vertices map[iotago.BlockID]*VisualizerVertex
+	// head is the index of the oldest entry.
+	head int
+	size int
+}
+
+func newVertexRing(capacity int) *vertexRing {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &vertexRing{
+		ids:      make([]iotago.BlockID, capacity),
+		vertices: make(map[iotago.BlockID]*VisualizerVertex, capacity),
+	}
+}
+
+// Capacity returns the maximum amount of vertices in the ring.
+func (r *vertexRing) Capacity() int {
+	return len(r.ids)
+}
+
+// Len returns the amount of vertices in the ring.
+func (r *vertexRing) Len() int {
+	return r.size
+}
+
+// Get returns the vertex with the given blockID.
+func (r *vertexRing) Get(blockID iotago.BlockID) (*VisualizerVertex, bool) {
+	vertex, exists := r.vertices[blockID]
+
+	return vertex, exists
+}
+
+// Add adds a new vertex to the ring. If the ring is full, the oldest vertex is evicted and returned.
+// The caller has to make sure that no vertex with the same blockID exists yet.
+func (r *vertexRing) Add(blockID iotago.BlockID, vertex *VisualizerVertex) *VisualizerVertex {
+	var evicted *VisualizerVertex
+
+	if r.size == len(r.ids) {
+		oldestID := r.ids[r.head]
+		evicted = r.vertices[oldestID]
+		delete(r.vertices, oldestID)
+
+		r.ids[r.head] = blockID
+		r.head = (r.head + 1) % len(r.ids)
+	} else {
+		r.ids[(r.head+r.size)%len(r.ids)] = blockID
+		r.size++
+	}
+
+	r.vertices[blockID] = vertex
+
+	return evicted
+}
+
+// ForEach iterates over the newest vertices in insertion order.
+// If count is smaller than zero or larger than the amount of vertices, all vertices are iterated.
+func (r *vertexRing) ForEach(consumer func(vertex *VisualizerVertex) bool, count int) {
+	start := 0
+	if count >= 0 && count < r.size {
+		start = r.size - count
+	}
+
+	for i := start; i < r.size; i++ {
+		if !consumer(r.vertices[r.ids[(r.head+i)%len(r.ids)]]) {
+			return
+		}
+	}
+}
+
+// Clear removes all vertices from the ring.
+func (r *vertexRing) Clear() {
+	r.ids = make([]iotago.BlockID, len(r.ids))
+	r.vertices = make(map[iotago.BlockID]*VisualizerVertex, len(r.ids))
+	r.head = 0
+	r.size = 0
+}
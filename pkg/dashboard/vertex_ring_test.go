@@ -0,0 +1,177 @@
+package dashboard
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+// testVertexIndex returns the index of a vertex whose block ID was created by testBlockID.
+func testVertexIndex(vertex *VisualizerVertex) int {
+	return int(binary.LittleEndian.Uint32(vertex.blockID[:]))
+}
+
+// ringIndexes returns the indexes of the newest vertices of the ring in insertion order.
+func ringIndexes(r *vertexRing, count int) []int {
+	indexes := make([]int, 0)
+	r.ForEach(func(vertex *VisualizerVertex) bool {
+		indexes = append(indexes, testVertexIndex(vertex))
+
+		return true
+	}, count)
+
+	return indexes
+}
+
+func equalInts(a []int, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestVertexRing(t *testing.T) {
+	const capacity = 3
+
+	tests := []struct {
+		name string
+		// added is the amount of vertices added to the ring.
+		added int
+		// count is the amount of newest vertices iterated.
+		count       int
+		wantLen     int
+		wantEvicted []int
+		wantIndexes []int
+	}{
+		{
+			name:        "empty",
+			count:       -1,
+			wantIndexes: []int{},
+		},
+		{
+			name:        "partially filled",
+			added:       2,
+			count:       -1,
+			wantLen:     2,
+			wantIndexes: []int{0, 1},
+		},
+		{
+			name:        "full",
+			added:       3,
+			count:       -1,
+			wantLen:     3,
+			wantIndexes: []int{0, 1, 2},
+		},
+		{
+			name:        "wrap around",
+			added:       5,
+			count:       -1,
+			wantLen:     3,
+			wantEvicted: []int{0, 1},
+			wantIndexes: []int{2, 3, 4},
+		},
+		{
+			name:        "wrap around multiple times",
+			added:       10,
+			count:       -1,
+			wantLen:     3,
+			wantEvicted: []int{0, 1, 2, 3, 4, 5, 6},
+			wantIndexes: []int{7, 8, 9},
+		},
+		{
+			name:        "newest vertices after wrap around",
+			added:       5,
+			count:       2,
+			wantLen:     3,
+			wantEvicted: []int{0, 1},
+			wantIndexes: []int{3, 4},
+		},
+		{
+			name:        "count larger than the capacity",
+			added:       5,
+			count:       capacity + 10,
+			wantLen:     3,
+			wantEvicted: []int{0, 1},
+			wantIndexes: []int{2, 3, 4},
+		},
+		{
+			name:        "count zero",
+			added:       2,
+			count:       0,
+			wantLen:     2,
+			wantIndexes: []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ring := newVertexRing(capacity)
+
+			evicted := make([]int, 0)
+			for i := 0; i < tt.added; i++ {
+				if vertex := ring.Add(testBlockID(i), newVertex(testBlockID(i))); vertex != nil {
+					evicted = append(evicted, testVertexIndex(vertex))
+				}
+			}
+
+			if ring.Len() != tt.wantLen {
+				t.Errorf("expected a length of %d, got %d", tt.wantLen, ring.Len())
+			}
+			if ring.Capacity() != capacity {
+				t.Errorf("expected a capacity of %d, got %d", capacity, ring.Capacity())
+			}
+			if !equalInts(evicted, tt.wantEvicted) {
+				t.Errorf("expected the evicted vertices %v, got %v", tt.wantEvicted, evicted)
+			}
+			if indexes := ringIndexes(ring, tt.count); !equalInts(indexes, tt.wantIndexes) {
+				t.Errorf("expected the vertices %v, got %v", tt.wantIndexes, indexes)
+			}
+
+			// evicted vertices can no longer be looked up
+			for i := 0; i < tt.added; i++ {
+				_, exists := ring.Get(testBlockID(i))
+				wantExists := i >= tt.added-tt.wantLen
+				if exists != wantExists {
+					t.Errorf("vertex %d: expected exists to be %t", i, wantExists)
+				}
+			}
+		})
+	}
+}
+
+func TestVisualizerInitValuesLargerThanCapacity(t *testing.T) {
+	const capacity = 5
+
+	blocks := generateBlocks(2 * capacity)
+	visualizer := NewVisualizer(nil, nil, capacity, 0)
+	for _, block := range blocks {
+		visualizer.AddVertex(block)
+	}
+
+	// the visualizer is initialized with more values than it can hold
+	vertices := make([]*VisualizerVertex, 0)
+	visualizer.ForEachCreated(func(vertex *VisualizerVertex) bool {
+		vertices = append(vertices, vertex)
+
+		return true
+	}, 3*capacity)
+
+	if len(vertices) != capacity {
+		t.Fatalf("expected %d vertices, got %d", capacity, len(vertices))
+	}
+	for i, vertex := range vertices {
+		if wantID := blocks[capacity+i].MustID(); vertex.blockID != wantID {
+			t.Errorf("vertex %d: expected %s, got %s", i, wantID.ToHex(), vertex.ID)
+		}
+	}
+
+	visualizer.clear()
+	if visualizer.vertices.Len() != 0 {
+		t.Errorf("expected an empty ring after clearing, got %d vertices", visualizer.vertices.Len())
+	}
+}
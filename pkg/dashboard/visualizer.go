@@ -2,10 +2,9 @@ package dashboard
 
 import (
 	"context"
-	"fmt"
 	"sync"
+	"time"
 
-	"github.com/iancoleman/orderedmap"
 	"go.uber.org/atomic"
 
 	"github.com/iotaledger/hive.go/lo"
@@ -19,6 +18,11 @@ import (
 
 const (
 	VisualizerIDLength = 10
+
+	PayloadKindNone        = "none"
+	PayloadKindTaggedData  = "taggedData"
+	PayloadKindTransaction = "transaction"
+	PayloadKindMilestone   = "milestone"
 )
 
 type Visualizer struct {
@@ -29,10 +33,16 @@ type Visualizer struct {
 
 	nodeBridge *nodebridge.NodeBridge
 
-	vertices *orderedmap.OrderedMap
-	capacity int
+	vertices *vertexRing
 	running  *atomic.Bool
-	active   *atomic.Bool
+	// active is true while the visualizer listens to blocks, which includes the linger period.
+	active *atomic.Bool
+	// subscribed is true while there are subscribers or the visualizer is always active.
+	subscribed   bool
+	lingerPeriod time.Duration
+	lingerTimer  *time.Timer
+	// lingerGeneration identifies the latest linger timer, timers that were stopped or replaced may still fire.
+	lingerGeneration uint64
 	//nolint:containedctx // false positive
 	ctx                     context.Context
 	ctxCancelListenToBlocks context.CancelFunc
@@ -47,21 +57,30 @@ type VisualizerEvents struct {
 	VertexTipUpdated   *event.Event1[*VisualizerVertex]
 	// params: milestoneParents []string, excludedIDs []string
 	Confirmation *event.Event2[[]string, []string]
+	// BlockSolidified is triggered with the time between the arrival and the solidification of a block.
+	BlockSolidified *event.Event1[time.Duration]
+	// BlockReferenced is triggered with the time between the arrival of a block and its reference by a milestone.
+	BlockReferenced *event.Event1[time.Duration]
 }
 
-func NewVisualizer(log *logger.Logger, nodeBridge *nodebridge.NodeBridge, capacity int) *Visualizer {
+// NewVisualizer creates a new visualizer.
+// The visualizer keeps listening to blocks for the linger period after the last subscriber left,
+// so that returning viewers get the full window. A linger period of zero stops listening immediately.
+func NewVisualizer(log *logger.Logger, nodeBridge *nodebridge.NodeBridge, capacity int, lingerPeriod time.Duration) *Visualizer {
 	return &Visualizer{
 		WrappedLogger: logger.NewWrappedLogger(log),
 		nodeBridge:    nodeBridge,
-		vertices:      orderedmap.New(),
-		capacity:      capacity,
+		vertices:      newVertexRing(capacity),
 		running:       atomic.NewBool(false),
 		active:        atomic.NewBool(false),
+		lingerPeriod:  lingerPeriod,
 		Events: &VisualizerEvents{
 			VertexCreated:      event.New1[*VisualizerVertex](),
 			VertexSolidUpdated: event.New1[*VisualizerVertex](),
 			VertexTipUpdated:   event.New1[*VisualizerVertex](),
 			Confirmation:       event.New2[[]string, []string](),
+			BlockSolidified:    event.New1[time.Duration](),
+			BlockReferenced:    event.New1[time.Duration](),
 		},
 	}
 }
@@ -78,7 +97,12 @@ func (v *Visualizer) Run(ctx context.Context) {
 	v.ctx = ctx
 }
 
-func (v *Visualizer) UpdateState(active bool) {
+// IsActive returns whether the visualizer listens to blocks.
+func (v *Visualizer) IsActive() bool {
+	return v.active.Load()
+}
+
+func (v *Visualizer) UpdateState(subscribed bool) {
 	if !v.running.Load() {
 		// do not update the state until the visualizer is running
 		return
@@ -87,20 +111,70 @@ func (v *Visualizer) UpdateState(active bool) {
 	v.Lock()
 	defer v.Unlock()
 
-	if oldActive := v.active.Swap(active); oldActive == active {
-		// state didn't change
+	v.subscribed = subscribed
+
+	if subscribed {
+		// a pending deactivation is no longer needed
+		v.stopLingerTimer()
+
+		if !v.active.Swap(true) {
+			// visualizer was activated => subscribe to INX streams
+			v.startListenToBlocks()
+		}
+
 		return
 	}
 
-	// state changed => subscribe or unsubscribe from INX streams
-	if active {
-		v.startListenToBlocks()
+	if !v.active.Load() {
+		// visualizer is not active
+		return
+	}
+
+	if v.lingerPeriod <= 0 {
+		v.deactivate()
 
 		return
 	}
 
-	// visualizer was deactivated
-	// stop listening to blocks
+	if v.lingerTimer != nil {
+		// deactivation is already pending
+		return
+	}
+
+	v.lingerGeneration++
+	lingerGeneration := v.lingerGeneration
+	v.lingerTimer = time.AfterFunc(v.lingerPeriod, func() {
+		v.onLingerPeriodExpired(lingerGeneration)
+	})
+}
+
+func (v *Visualizer) onLingerPeriodExpired(lingerGeneration uint64) {
+	v.Lock()
+	defer v.Unlock()
+
+	if v.lingerTimer == nil || v.lingerGeneration != lingerGeneration {
+		// the timer was stopped or replaced in the meantime
+		return
+	}
+	v.lingerTimer = nil
+
+	if v.subscribed || !v.active.Load() {
+		return
+	}
+
+	v.deactivate()
+}
+
+func (v *Visualizer) stopLingerTimer() {
+	if v.lingerTimer != nil {
+		v.lingerTimer.Stop()
+		v.lingerTimer = nil
+	}
+}
+
+// deactivate stops listening to blocks and clears the visualizer.
+func (v *Visualizer) deactivate() {
+	v.active.Store(false)
 	v.stopListenToBlocks()
 }
 
@@ -139,40 +213,47 @@ func (v *Visualizer) startListenToBlocks() {
 }
 
 func (v *Visualizer) clear() {
-	v.vertices = orderedmap.New()
-}
-
-func (v *Visualizer) removeOldEntries() {
-	// remove old entries
-	//nolint:ifshort // false positive
-	keys := v.vertices.Keys()
-	if len(keys) >= v.capacity {
-		v.vertices.Delete(keys[0])
-	}
+	v.vertices.Clear()
 }
 
 func newVertex(blockID iotago.BlockID) *VisualizerVertex {
 	return &VisualizerVertex{
 		ID:      blockID.ToHex(),
+		blockID: blockID,
 		shortID: blockID.ToHex()[:VisualizerIDLength],
 	}
 }
 
-func (v *Visualizer) getEntry(blockID iotago.BlockID) (*VisualizerVertex, bool) {
-	id := string(blockID[:])
-
-	var vertex *VisualizerVertex
-	vert, exists := v.vertices.Get(id)
-	if exists {
-		var ok bool
-		vertex, ok = vert.(*VisualizerVertex)
-		if !ok {
-			panic(fmt.Sprintf("expected *VisualizerVertex, got %T", vert))
+// payloadKindAndTag returns the kind of the payload and the hex encoded tag
+// of the tagged data payload, which may also be embedded in a transaction.
+func payloadKindAndTag(payload iotago.Payload) (string, string) {
+	switch p := payload.(type) {
+	case *iotago.TaggedData:
+		return PayloadKindTaggedData, iotago.EncodeHex(p.Tag)
+
+	case *iotago.Transaction:
+		if p.Essence != nil {
+			if taggedData, ok := p.Essence.Payload.(*iotago.TaggedData); ok {
+				return PayloadKindTransaction, iotago.EncodeHex(taggedData.Tag)
+			}
 		}
-	} else {
+
+		return PayloadKindTransaction, ""
+
+	case *iotago.Milestone:
+		return PayloadKindMilestone, ""
+
+	default:
+		return PayloadKindNone, ""
+	}
+}
+
+func (v *Visualizer) getEntry(blockID iotago.BlockID) (*VisualizerVertex, bool) {
+	vertex, exists := v.vertices.Get(blockID)
+	if !exists {
 		vertex = newVertex(blockID)
-		v.vertices.Set(id, vertex)
-		v.removeOldEntries()
+		// the oldest vertex is evicted if the capacity is reached
+		v.vertices.Add(blockID, vertex)
 	}
 
 	return vertex, exists
@@ -192,21 +273,52 @@ func (v *Visualizer) AddVertex(block *iotago.Block) {
 
 	vertex, _ := v.getEntry(blockID)
 	vertex.Parents = parentsHex
+	vertex.parents = block.Parents
 	vertex.isCreated = true
 	vertex.IsTip = !vertex.isReferencedByOthers
 	vertex.IsTransaction = block.Payload != nil && block.Payload.PayloadType() == iotago.PayloadTransaction
 	vertex.IsMilestone = block.Payload != nil && block.Payload.PayloadType() == iotago.PayloadMilestone
+	vertex.PayloadKind, vertex.Tag = payloadKindAndTag(block.Payload)
+	if vertex.ArrivalTime == 0 {
+		v.setArrivalTime(vertex, time.Now().UnixMilli())
+	}
 
 	// always trigger the created event, even if the vertex existed before, it was not send yet
 	v.Events.VertexCreated.Trigger(vertex)
 }
 
+// setArrivalTime sets the arrival time of a created vertex.
+// The solid and referenced events are received independently of the block and may arrive first,
+// in that case the earliest event counts as the arrival and the latencies that were held back are reported.
+func (v *Visualizer) setArrivalTime(vertex *VisualizerVertex, now int64) {
+	vertex.ArrivalTime = now
+	if vertex.SolidTime != 0 && vertex.SolidTime < vertex.ArrivalTime {
+		vertex.ArrivalTime = vertex.SolidTime
+	}
+	if vertex.ReferencedTime != 0 && vertex.ReferencedTime < vertex.ArrivalTime {
+		vertex.ArrivalTime = vertex.ReferencedTime
+	}
+
+	if vertex.SolidTime != 0 {
+		v.Events.BlockSolidified.Trigger(time.Duration(vertex.SolidTime-vertex.ArrivalTime) * time.Millisecond)
+	}
+	if vertex.ReferencedTime != 0 {
+		v.Events.BlockReferenced.Trigger(time.Duration(vertex.ReferencedTime-vertex.ArrivalTime) * time.Millisecond)
+	}
+}
+
 func (v *Visualizer) SetIsSolid(blockID iotago.BlockID) {
 	v.Lock()
 	defer v.Unlock()
 
 	vertex, exists := v.getEntry(blockID)
 	vertex.IsSolid = true
+	if vertex.SolidTime == 0 {
+		vertex.SolidTime = time.Now().UnixMilli()
+		if vertex.ArrivalTime != 0 {
+			v.Events.BlockSolidified.Trigger(time.Duration(vertex.SolidTime-vertex.ArrivalTime) * time.Millisecond)
+		}
+	}
 	if exists {
 		// trigger the solid event only if the vertex was already created
 		v.Events.VertexSolidUpdated.Trigger(vertex)
@@ -219,6 +331,12 @@ func (v *Visualizer) SetIsReferenced(blockID iotago.BlockID) {
 
 	vertex, _ := v.getEntry(blockID)
 	vertex.IsReferenced = true
+	if vertex.ReferencedTime == 0 {
+		vertex.ReferencedTime = time.Now().UnixMilli()
+		if vertex.ArrivalTime != 0 {
+			v.Events.BlockReferenced.Trigger(time.Duration(vertex.ReferencedTime-vertex.ArrivalTime) * time.Millisecond)
+		}
+	}
 }
 
 func (v *Visualizer) SetIsConflicting(blockID iotago.BlockID) {
@@ -264,29 +382,18 @@ func (v *Visualizer) ForEachCreated(consumer func(vertex *VisualizerVertex) bool
 	v.RLock()
 	defer v.RUnlock()
 
-	keys := v.vertices.Keys()
-
-	if len(elementsCount) > 0 && len(keys) > elementsCount[0] {
-		keys = keys[len(keys)-elementsCount[0]:]
+	count := -1
+	if len(elementsCount) > 0 {
+		count = elementsCount[0]
 	}
 
-	for _, key := range keys {
-		v, exists := v.vertices.Get(key)
-		if !exists {
-			continue
-		}
-
-		vertex, ok := v.(*VisualizerVertex)
-		if !ok {
-			panic(fmt.Sprintf("expected *VisualizerVertex, got %T", v))
+	v.vertices.ForEach(func(vertex *VisualizerVertex) bool {
+		if !vertex.isCreated {
+			return true
 		}
 
-		if vertex.isCreated {
-			if !consumer(vertex) {
-				break
-			}
-		}
-	}
+		return consumer(vertex)
+	}, count)
 }
 
 func (v *Visualizer) ApplyConfirmedMilestoneChanged(ms *nodebridge.Milestone) {
@@ -301,7 +408,7 @@ func (v *Visualizer) ApplyConfirmedMilestoneChanged(ms *nodebridge.Milestone) {
 
 	conflictingBlocks := iotago.BlockIDs{}
 
-	if err := v.nodeBridge.MilestoneConeMetadata(ctx, cancel, ms.Milestone.Index, func(metadata *inx.BlockMetadata) {
+	if err := walkMilestoneCone(ctx, v.nodeBridge, ms.Milestone.Index, func(metadata *inx.BlockMetadata) {
 		blockMeta := blockMetadataFromINXBlockMetadata(metadata)
 
 		v.SetIsReferenced(blockMeta.BlockID)
@@ -311,6 +418,7 @@ func (v *Visualizer) ApplyConfirmedMilestoneChanged(ms *nodebridge.Milestone) {
 			conflictingBlocks = append(conflictingBlocks, blockMeta.BlockID)
 		}
 	}); err != nil {
+		// the parents of the milestone are confirmed anyway, only the excluded blocks may be incomplete
 		v.LogWarnf("failed to get milestone cone metadata: %v", err)
 	}
 
@@ -411,6 +519,9 @@ func (d *Dashboard) runVisualizerFeed() {
 
 		d.visualizer.Run(ctx)
 
+		// the visualizer may need to be active without any subscribers
+		d.checkVisualizerSubscriptions()
+
 		<-ctx.Done()
 		unhook()
 
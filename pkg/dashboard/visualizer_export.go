@@ -0,0 +1,187 @@
+package dashboard
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/inx-dashboard/pkg/common"
+)
+
+const (
+	// QueryParameterFormat is used to define the format of the visualizer snapshot.
+	QueryParameterFormat = "format"
+
+	VisualizerSnapshotFormatJSON    = "json"
+	VisualizerSnapshotFormatDOT     = "dot"
+	VisualizerSnapshotFormatGraphML = "graphml"
+
+	MIMETextVNDGraphviz    = "text/vnd.graphviz"
+	MIMEApplicationGraphML = "application/graphml+xml"
+)
+
+// VisualizerSnapshot is a copy of the vertices currently held by the visualizer.
+// Unlike in the websocket messages, the parents of the vertices are referenced by their full IDs.
+type VisualizerSnapshot struct {
+	Vertices []*VisualizerVertex `json:"vertices"`
+}
+
+// Snapshot returns a copy of all created vertices, with the full IDs of their parents.
+func (v *Visualizer) Snapshot() *VisualizerSnapshot {
+	snapshot := &VisualizerSnapshot{
+		Vertices: make([]*VisualizerVertex, 0),
+	}
+
+	v.ForEachCreated(func(vertex *VisualizerVertex) bool {
+		vertexCopy := *vertex
+		vertexCopy.Parents = vertex.parentIDs()
+		snapshot.Vertices = append(snapshot.Vertices, &vertexCopy)
+
+		return true
+	})
+
+	return snapshot
+}
+
+// parentIDs returns the full hex encoded IDs of the parents of the vertex.
+// The short IDs in Parents are enough to draw the visualizer, but they can collide in exported graphs.
+func (v *VisualizerVertex) parentIDs() []string {
+	parentIDs := make([]string, len(v.parents))
+	for i, parent := range v.parents {
+		parentIDs[i] = parent.ToHex()
+	}
+
+	return parentIDs
+}
+
+// DOT serializes the snapshot in the Graphviz DOT format.
+// Vertices are identified by their block ID, edges point from a block to its parents.
+func (s *VisualizerSnapshot) DOT() []byte {
+	var b bytes.Buffer
+
+	b.WriteString("digraph tangle {\n")
+	for _, vertex := range s.Vertices {
+		fmt.Fprintf(&b, "  %q [label=%q, solid=%t, referenced=%t, conflicting=%t, transaction=%t, milestone=%t, tip=%t];\n",
+			vertex.ID, vertex.shortID, vertex.IsSolid, vertex.IsReferenced, vertex.IsConflicting, vertex.IsTransaction, vertex.IsMilestone, vertex.IsTip)
+	}
+	for _, vertex := range s.Vertices {
+		for _, parentID := range vertex.parentIDs() {
+			fmt.Fprintf(&b, "  %q -> %q;\n", vertex.ID, parentID)
+		}
+	}
+	b.WriteString("}\n")
+
+	return b.Bytes()
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string `xml:"source,attr"`
+	Target string `xml:"target,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLDocument struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+// GraphML serializes the snapshot in the GraphML format.
+// Vertices are identified by their block ID, edges point from a block to its parents.
+func (s *VisualizerSnapshot) GraphML() ([]byte, error) {
+	flags := []string{"solid", "referenced", "conflicting", "transaction", "milestone", "tip"}
+
+	doc := &graphMLDocument{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "label", For: "node", Name: "label", Type: "string"},
+		},
+		Graph: graphMLGraph{
+			ID:          "tangle",
+			EdgeDefault: "directed",
+			Nodes:       make([]graphMLNode, 0, len(s.Vertices)),
+			Edges:       make([]graphMLEdge, 0),
+		},
+	}
+
+	for _, flag := range flags {
+		doc.Keys = append(doc.Keys, graphMLKey{ID: flag, For: "node", Name: flag, Type: "boolean"})
+	}
+
+	for _, vertex := range s.Vertices {
+		values := []bool{vertex.IsSolid, vertex.IsReferenced, vertex.IsConflicting, vertex.IsTransaction, vertex.IsMilestone, vertex.IsTip}
+
+		node := graphMLNode{
+			ID:   vertex.ID,
+			Data: []graphMLData{{Key: "label", Value: vertex.shortID}},
+		}
+		for i, flag := range flags {
+			node.Data = append(node.Data, graphMLData{Key: flag, Value: fmt.Sprintf("%t", values[i])})
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, node)
+
+		for _, parentID := range vertex.parentIDs() {
+			doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{Source: vertex.ID, Target: parentID})
+		}
+	}
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
+
+func (d *Dashboard) visualizerSnapshotRoute(c echo.Context) error {
+	snapshot := d.visualizer.Snapshot()
+
+	format := strings.ToLower(c.QueryParam(QueryParameterFormat))
+	switch format {
+	case "", VisualizerSnapshotFormatJSON:
+		return c.JSON(http.StatusOK, snapshot)
+
+	case VisualizerSnapshotFormatDOT:
+		return c.Blob(http.StatusOK, MIMETextVNDGraphviz, snapshot.DOT())
+
+	case VisualizerSnapshotFormatGraphML:
+		data, err := snapshot.GraphML()
+		if err != nil {
+			return err
+		}
+
+		return c.Blob(http.StatusOK, MIMEApplicationGraphML, data)
+
+	default:
+		return errors.WithMessagef(common.ErrInvalidParameter, "invalid format: %s, supported formats: %s, %s, %s", format, VisualizerSnapshotFormatJSON, VisualizerSnapshotFormatDOT, VisualizerSnapshotFormatGraphML)
+	}
+}
@@ -0,0 +1,175 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/inx-dashboard/pkg/common"
+)
+
+func TestVisualizerSnapshotFormats(t *testing.T) {
+	blocks := generateBlocks(3)
+
+	visualizer := NewVisualizer(nil, nil, 10, 0)
+	for _, block := range blocks {
+		visualizer.AddVertex(block)
+	}
+	d := &Dashboard{visualizer: visualizer}
+
+	// every block references all previous blocks, the first one the empty block ID
+	edges := []string{
+		blocks[0].MustID().ToHex() + " -> " + "0x" + strings.Repeat("00", 32),
+		blocks[1].MustID().ToHex() + " -> " + blocks[0].MustID().ToHex(),
+		blocks[2].MustID().ToHex() + " -> " + blocks[1].MustID().ToHex(),
+		blocks[2].MustID().ToHex() + " -> " + blocks[0].MustID().ToHex(),
+	}
+
+	tests := []struct {
+		name            string
+		format          string
+		wantContentType string
+		check           func(t *testing.T, body string)
+		wantErr         bool
+	}{
+		{
+			name:            "default",
+			format:          "",
+			wantContentType: echo.MIMEApplicationJSON,
+			check: func(t *testing.T, body string) {
+				t.Helper()
+
+				var snapshot VisualizerSnapshot
+				if err := json.Unmarshal([]byte(body), &snapshot); err != nil {
+					t.Fatal(err)
+				}
+
+				if len(snapshot.Vertices) != len(blocks) {
+					t.Fatalf("expected %d vertices, got %d", len(blocks), len(snapshot.Vertices))
+				}
+
+				// the parents are referenced by their full IDs
+				edgeCount := 0
+				for _, vertex := range snapshot.Vertices {
+					for _, parentID := range vertex.Parents {
+						found := false
+						for _, edge := range edges {
+							if vertex.ID+" -> "+parentID == edge {
+								found = true
+							}
+						}
+						if !found {
+							t.Errorf("unexpected parent %s of vertex %s", parentID, vertex.ID)
+						}
+						edgeCount++
+					}
+				}
+				if edgeCount != len(edges) {
+					t.Errorf("expected %d parents, got %d", len(edges), edgeCount)
+				}
+			},
+		},
+		{
+			name:            "dot",
+			format:          VisualizerSnapshotFormatDOT,
+			wantContentType: MIMETextVNDGraphviz,
+			check: func(t *testing.T, body string) {
+				t.Helper()
+
+				if !strings.HasPrefix(body, "digraph tangle {\n") || !strings.HasSuffix(body, "}\n") {
+					t.Errorf("expected a digraph, got %s", body)
+				}
+				for _, block := range blocks {
+					blockID := block.MustID().ToHex()
+					if !strings.Contains(body, `"`+blockID+`" [label="`+blockID[:VisualizerIDLength]+`", solid=false`) {
+						t.Errorf("expected a node of block %s", blockID)
+					}
+				}
+				for _, edge := range edges {
+					ids := strings.Split(edge, " -> ")
+					if !strings.Contains(body, `"`+ids[0]+`" -> "`+ids[1]+`";`) {
+						t.Errorf("expected the edge %s", edge)
+					}
+				}
+				if count := strings.Count(body, " -> "); count != len(edges) {
+					t.Errorf("expected %d edges, got %d", len(edges), count)
+				}
+			},
+		},
+		{
+			name:            "graphml",
+			format:          strings.ToUpper(VisualizerSnapshotFormatGraphML),
+			wantContentType: MIMEApplicationGraphML,
+			check: func(t *testing.T, body string) {
+				t.Helper()
+
+				var doc graphMLDocument
+				if err := xml.Unmarshal([]byte(body), &doc); err != nil {
+					t.Fatal(err)
+				}
+
+				if len(doc.Graph.Nodes) != len(blocks) {
+					t.Fatalf("expected %d nodes, got %d", len(blocks), len(doc.Graph.Nodes))
+				}
+				for _, node := range doc.Graph.Nodes {
+					// the label and the flags
+					if len(node.Data) != len(doc.Keys) {
+						t.Errorf("node %s: expected %d data entries, got %d", node.ID, len(doc.Keys), len(node.Data))
+					}
+				}
+
+				if len(doc.Graph.Edges) != len(edges) {
+					t.Fatalf("expected %d edges, got %d", len(edges), len(doc.Graph.Edges))
+				}
+				for _, edge := range doc.Graph.Edges {
+					found := false
+					for _, wantEdge := range edges {
+						if edge.Source+" -> "+edge.Target == wantEdge {
+							found = true
+						}
+					}
+					if !found {
+						t.Errorf("unexpected edge %s -> %s", edge.Source, edge.Target)
+					}
+				}
+			},
+		},
+		{
+			name:    "unknown format",
+			format:  "svg",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/?"+QueryParameterFormat+"="+tt.format, nil), rec)
+
+			err := d.visualizerSnapshotRoute(c)
+			if tt.wantErr {
+				if !errors.Is(err, common.ErrInvalidParameter) {
+					t.Fatalf("expected an invalid parameter error, got %v", err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if contentType := rec.Header().Get(echo.HeaderContentType); !strings.HasPrefix(contentType, tt.wantContentType) {
+				t.Errorf("expected content type %s, got %s", tt.wantContentType, contentType)
+			}
+			if tt.check != nil {
+				tt.check(t, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package dashboard
+
+import (
+	"context"
+	"time"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	// VisualizerHighlightMaxNodeLookups is the maximum amount of blocks that are
+	// looked up in the node while tracing the past cone of a highlighted block.
+	VisualizerHighlightMaxNodeLookups = 50
+	// VisualizerHighlightInterval is the interval in which a client regains a highlight request.
+	VisualizerHighlightInterval = 2 * time.Second
+	// VisualizerHighlightBurst is the maximum amount of highlight requests a client can send at once.
+	VisualizerHighlightBurst = 3
+)
+
+// Parents returns the full parent IDs of a vertex that was created in the visualizer.
+func (v *Visualizer) Parents(blockID iotago.BlockID) (iotago.BlockIDs, bool) {
+	v.RLock()
+	defer v.RUnlock()
+
+	vertex, exists := v.vertices.Get(blockID)
+	if !exists || !vertex.isCreated {
+		return nil, false
+	}
+
+	return vertex.parents, true
+}
+
+// FutureCone returns the IDs of all vertices in the visualizer that directly or indirectly approve the given block.
+func (v *Visualizer) FutureCone(blockID iotago.BlockID) iotago.BlockIDs {
+	v.RLock()
+	defer v.RUnlock()
+
+	approvers := make(map[iotago.BlockID]iotago.BlockIDs)
+	v.vertices.ForEach(func(vertex *VisualizerVertex) bool {
+		if !vertex.isCreated {
+			return true
+		}
+
+		for _, parent := range vertex.parents {
+			approvers[parent] = append(approvers[parent], vertex.blockID)
+		}
+
+		return true
+	}, -1)
+
+	futureCone := iotago.BlockIDs{}
+	visited := map[iotago.BlockID]struct{}{blockID: {}}
+	stack := append(iotago.BlockIDs{}, approvers[blockID]...)
+	for len(stack) > 0 {
+		approver := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if _, seen := visited[approver]; seen {
+			continue
+		}
+		visited[approver] = struct{}{}
+
+		futureCone = append(futureCone, approver)
+		stack = append(stack, approvers[approver]...)
+	}
+
+	return futureCone
+}
+
+// getVisualizerHighlight traces the past and the future cone of the given block.
+// The past cone is traced via the parents of the vertices in the visualizer,
+// blocks that are not part of the visualizer window are looked up in the node.
+// The future cone only contains approvers within the visualizer window.
+func (d *Dashboard) getVisualizerHighlight(ctx context.Context, blockID iotago.BlockID) (*VisualizerHighlight, error) {
+	lookups := 0
+
+	parentsOf := func(id iotago.BlockID) (iotago.BlockIDs, bool, error) {
+		if parents, exists := d.visualizer.Parents(id); exists {
+			return parents, true, nil
+		}
+
+		if lookups >= VisualizerHighlightMaxNodeLookups {
+			return nil, false, nil
+		}
+		lookups++
+
+		ctxNode, ctxNodeCancel := context.WithTimeout(ctx, nodeTimeout)
+		defer ctxNodeCancel()
+
+		metadata, err := d.nodeBridge.BlockMetadata(ctxNode, id)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return metadata.UnwrapParents(), true, nil
+	}
+
+	parents, found, err := parentsOf(blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	highlight := &VisualizerHighlight{
+		ID:         blockID.ToHex(),
+		PastCone:   make([]string, 0),
+		FutureCone: shortIDs(d.visualizer.FutureCone(blockID)),
+		Truncated:  !found,
+	}
+
+	visited := map[iotago.BlockID]struct{}{blockID: {}}
+	stack := append(iotago.BlockIDs{}, parents...)
+	for len(stack) > 0 {
+		parent := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if _, seen := visited[parent]; seen {
+			continue
+		}
+		visited[parent] = struct{}{}
+
+		if parent == iotago.EmptyBlockID() {
+			// genesis
+			continue
+		}
+
+		highlight.PastCone = append(highlight.PastCone, parent.ToHex()[:VisualizerIDLength])
+
+		grandParents, found, err := parentsOf(parent)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			// the block may have been pruned already
+			highlight.Truncated = true
+
+			continue
+		}
+		if !found {
+			highlight.Truncated = true
+
+			continue
+		}
+
+		stack = append(stack, grandParents...)
+	}
+
+	return highlight, nil
+}
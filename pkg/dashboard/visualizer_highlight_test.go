@@ -0,0 +1,134 @@
+package dashboard
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+func TestVisualizerFutureCone(t *testing.T) {
+	// genesis <- a <- c <- d
+	//         <- b <-/
+	// missing <- f
+	genesisID, genesis := testBlock(1, iotago.EmptyBlockID())
+	aID, a := testBlock(2, genesisID)
+	bID, b := testBlock(3, genesisID)
+	cID, c := testBlock(4, aID, bID)
+	dID, d := testBlock(5, cID)
+	missingID, _ := testBlock(6, iotago.EmptyBlockID())
+	fID, f := testBlock(7, missingID)
+
+	visualizer := NewVisualizer(nil, nil, 10, 0)
+	for _, block := range []*iotago.Block{genesis, a, b, c, d, f} {
+		visualizer.AddVertex(block)
+	}
+
+	tests := []struct {
+		name    string
+		blockID iotago.BlockID
+		want    iotago.BlockIDs
+	}{
+		{
+			name:    "shared approver is contained once",
+			blockID: genesisID,
+			want:    iotago.BlockIDs{aID, bID, cID, dID},
+		},
+		{
+			name:    "single approver",
+			blockID: cID,
+			want:    iotago.BlockIDs{dID},
+		},
+		{
+			name:    "tip",
+			blockID: dID,
+			want:    iotago.BlockIDs{},
+		},
+		{
+			name:    "block outside of the window",
+			blockID: missingID,
+			want:    iotago.BlockIDs{fID},
+		},
+		{
+			name:    "unknown block",
+			blockID: testBlockID(100),
+			want:    iotago.BlockIDs{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			futureCone := visualizer.FutureCone(tt.blockID)
+			sort.Slice(futureCone, func(i, j int) bool {
+				return futureCone[i].ToHex() < futureCone[j].ToHex()
+			})
+			want := tt.want.RemoveDupsAndSort()
+
+			if len(futureCone) != len(want) {
+				t.Fatalf("expected %d approvers, got %d", len(want), len(futureCone))
+			}
+			for i := range want {
+				if futureCone[i] != want[i] {
+					t.Errorf("expected approver %s, got %s", want[i].ToHex(), futureCone[i].ToHex())
+				}
+			}
+		})
+	}
+}
+
+func TestLatestRequestCancelsPreviousRequest(t *testing.T) {
+	// blockingRequest returns a request that blocks until it is canceled and reports its cancellation.
+	blockingRequest := func(started chan struct{}, canceled chan struct{}) func(ctx context.Context) {
+		return func(ctx context.Context) {
+			close(started)
+			<-ctx.Done()
+			close(canceled)
+		}
+	}
+
+	waitFor := func(t *testing.T, ch chan struct{}, what string) {
+		t.Helper()
+
+		select {
+		case <-ch:
+		case <-time.After(time.Second):
+			t.Fatalf("expected the %s", what)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	request := &latestRequest{}
+
+	firstStarted, firstCanceled := make(chan struct{}), make(chan struct{})
+	request.start(ctx, blockingRequest(firstStarted, firstCanceled))
+	waitFor(t, firstStarted, "first request to start")
+
+	secondStarted, secondCanceled := make(chan struct{}), make(chan struct{})
+	request.start(ctx, blockingRequest(secondStarted, secondCanceled))
+	waitFor(t, firstCanceled, "first request to be canceled by the second one")
+	waitFor(t, secondStarted, "second request to start")
+
+	select {
+	case <-secondCanceled:
+		t.Fatal("expected the latest request to keep running")
+	default:
+	}
+
+	request.stop()
+	waitFor(t, secondCanceled, "second request to be canceled by stop")
+
+	// requests are canceled with the context of the client
+	thirdStarted, thirdCanceled := make(chan struct{}), make(chan struct{})
+	request.start(ctx, blockingRequest(thirdStarted, thirdCanceled))
+	waitFor(t, thirdStarted, "third request to start")
+	cancel()
+	waitFor(t, thirdCanceled, "third request to be canceled with the client context")
+
+	// stopping without a running request is a no-op
+	request.stop()
+	request.stop()
+}
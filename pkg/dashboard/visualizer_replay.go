@@ -0,0 +1,187 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/binary"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/web/websockethub"
+	"github.com/iotaledger/inx-dashboard/pkg/common"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	// VisualizerReplayMaxMilestones is the maximum amount of milestones that can be replayed at once.
+	VisualizerReplayMaxMilestones = 100
+	// visualizerReplayRequestLength is the length of a serialized replay request.
+	visualizerReplayRequestLength = 12
+)
+
+// VisualizerReplayRequest defines the milestone range and the speed of a visualizer replay.
+type VisualizerReplayRequest struct {
+	StartIndex uint32
+	EndIndex   uint32
+	// BlocksPerSecond is the speed of the replay, zero replays the blocks as fast as possible.
+	BlocksPerSecond uint32
+}
+
+// parseVisualizerReplayRequest parses a replay request.
+// The request consists of the little endian encoded start index, end index and blocks per second (uint32 each).
+func parseVisualizerReplayRequest(data []byte) (*VisualizerReplayRequest, error) {
+	if len(data) != visualizerReplayRequestLength {
+		return nil, errors.WithMessagef(common.ErrInvalidParameter, "invalid replay request length: %d, expected: %d", len(data), visualizerReplayRequestLength)
+	}
+
+	request := &VisualizerReplayRequest{
+		StartIndex:      binary.LittleEndian.Uint32(data[0:4]),
+		EndIndex:        binary.LittleEndian.Uint32(data[4:8]),
+		BlocksPerSecond: binary.LittleEndian.Uint32(data[8:12]),
+	}
+
+	if request.StartIndex == 0 || request.StartIndex > request.EndIndex {
+		return nil, errors.WithMessagef(common.ErrInvalidParameter, "invalid replay range: %d-%d", request.StartIndex, request.EndIndex)
+	}
+
+	if request.EndIndex-request.StartIndex >= VisualizerReplayMaxMilestones {
+		return nil, errors.WithMessagef(common.ErrInvalidParameter, "replay range too large: %d-%d, max. %d milestones", request.StartIndex, request.EndIndex, VisualizerReplayMaxMilestones)
+	}
+
+	return request, nil
+}
+
+// shortIDs returns the shortened hex encoded block IDs used by the visualizer.
+func shortIDs(blockIDs iotago.BlockIDs) []string {
+	ids := make([]string, len(blockIDs))
+	for i, blockID := range blockIDs {
+		ids[i] = blockID.ToHex()[:VisualizerIDLength]
+	}
+
+	return ids
+}
+
+// replayVertex creates a vertex from a block of a confirmed milestone cone.
+func replayVertex(block *iotago.Block, blockMeta *BlockMetadata) *VisualizerVertex {
+	vertex := newVertex(blockMeta.BlockID)
+	vertex.Parents = shortIDs(block.Parents)
+	vertex.parents = block.Parents
+	vertex.IsSolid = blockMeta.IsSolid
+	vertex.IsReferenced = blockMeta.IsReferenced
+	vertex.IsConflicting = blockMeta.IsConflicting
+	vertex.IsTransaction = block.Payload != nil && block.Payload.PayloadType() == iotago.PayloadTransaction
+	vertex.IsMilestone = block.Payload != nil && block.Payload.PayloadType() == iotago.PayloadMilestone
+	vertex.PayloadKind, vertex.Tag = payloadKindAndTag(block.Payload)
+
+	return vertex
+}
+
+// replayMilestoneCones walks the cones of the requested milestones and sends
+// the contained blocks and the confirmation infos to the given client.
+// The replay stops if the context is canceled or the client disconnects.
+func (d *Dashboard) replayMilestoneCones(ctx context.Context, client *websockethub.Client, request *VisualizerReplayRequest) {
+	var interval time.Duration
+	if request.BlocksPerSecond > 0 {
+		interval = time.Second / time.Duration(request.BlocksPerSecond)
+	}
+
+	status := &VisualizerReplayStatus{
+		StartIndex: request.StartIndex,
+		EndIndex:   request.EndIndex,
+	}
+
+	sendStatus := func() {
+		ctxMsg, ctxMsgCancel := context.WithTimeout(ctx, d.websocketWriteTimeout)
+		defer ctxMsgCancel()
+
+		_ = client.Send(ctxMsg, &Msg{Type: MsgTypeVisualizerReplayStatus, Data: status}, true)
+	}
+
+	// send sends the message without dropping it, the replay waits for slow clients
+	send := func(msg *Msg) error {
+		ctxMsg, ctxMsgCancel := context.WithTimeout(ctx, d.websocketWriteTimeout)
+		defer ctxMsgCancel()
+
+		return client.Send(ctxMsg, msg, true)
+	}
+
+	for msIndex := request.StartIndex; msIndex <= request.EndIndex; msIndex++ {
+		status.CurrentIndex = msIndex
+		sendStatus()
+
+		if err := d.replayMilestoneCone(ctx, msIndex, interval, send); err != nil {
+			if ctx.Err() != nil {
+				// replay was canceled or the client disconnected
+				return
+			}
+
+			d.LogWarnf("failed to replay milestone %d: %s", msIndex, err)
+			status.Error = err.Error()
+			sendStatus()
+
+			return
+		}
+	}
+
+	status.Finished = true
+	sendStatus()
+}
+
+func (d *Dashboard) replayMilestoneCone(ctx context.Context, msIndex uint32, interval time.Duration, send func(msg *Msg) error) error {
+	ctxNode, ctxNodeCancel := context.WithTimeout(ctx, nodeTimeout)
+	defer ctxNodeCancel()
+
+	milestone, err := d.nodeBridge.Milestone(ctxNode, msIndex)
+	if err != nil {
+		return err
+	}
+
+	// collect the metadata first, the block lookups are throttled by the replay speed.
+	// a truncated cone fails the replay instead of being replayed as complete.
+	coneMetadata := make([]*BlockMetadata, 0)
+	ctxCone, ctxConeCancel := context.WithTimeout(ctx, nodeTimeout)
+	defer ctxConeCancel()
+
+	if err := walkMilestoneCone(ctxCone, d.nodeBridge, msIndex, func(metadata *inx.BlockMetadata) {
+		coneMetadata = append(coneMetadata, blockMetadataFromINXBlockMetadata(metadata))
+	}); err != nil {
+		return err
+	}
+	ctxConeCancel()
+
+	conflictingBlocks := iotago.BlockIDs{}
+	for _, blockMeta := range coneMetadata {
+		block, err := d.getBlock(ctx, blockMeta.BlockID)
+		if err != nil {
+			return err
+		}
+
+		if blockMeta.IsConflicting {
+			conflictingBlocks = append(conflictingBlocks, blockMeta.BlockID)
+		}
+
+		if err := send(&Msg{Type: MsgTypeVisualizerReplayVertex, Data: replayVertex(block, blockMeta)}); err != nil {
+			return err
+		}
+
+		if interval > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(interval):
+			}
+		}
+	}
+
+	return send(&Msg{
+		Type: MsgTypeVisualizerReplayConfirmedInfo,
+		Data: &VisualizerReplayConfirmationInfo{
+			Index:       msIndex,
+			MilestoneID: milestone.MilestoneID.ToHex(),
+			Timestamp:   milestone.Milestone.Timestamp,
+			IDs:         shortIDs(milestone.Milestone.Parents),
+			ExcludedIDs: shortIDs(conflictingBlocks),
+		},
+	})
+}
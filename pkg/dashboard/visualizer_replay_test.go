@@ -0,0 +1,182 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotaledger/inx-dashboard/pkg/common"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+func replayRequestData(startIndex uint32, endIndex uint32, blocksPerSecond uint32) []byte {
+	data := make([]byte, visualizerReplayRequestLength)
+	binary.LittleEndian.PutUint32(data[0:4], startIndex)
+	binary.LittleEndian.PutUint32(data[4:8], endIndex)
+	binary.LittleEndian.PutUint32(data[8:12], blocksPerSecond)
+
+	return data
+}
+
+func TestParseVisualizerReplayRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    *VisualizerReplayRequest
+		wantErr bool
+	}{
+		{
+			name: "single milestone",
+			data: replayRequestData(10, 10, 0),
+			want: &VisualizerReplayRequest{StartIndex: 10, EndIndex: 10},
+		},
+		{
+			name: "maximum range",
+			data: replayRequestData(1, VisualizerReplayMaxMilestones, 50),
+			want: &VisualizerReplayRequest{StartIndex: 1, EndIndex: VisualizerReplayMaxMilestones, BlocksPerSecond: 50},
+		},
+		{
+			name:    "range too large",
+			data:    replayRequestData(1, VisualizerReplayMaxMilestones+1, 0),
+			wantErr: true,
+		},
+		{
+			name:    "start index zero",
+			data:    replayRequestData(0, 10, 0),
+			wantErr: true,
+		},
+		{
+			name:    "start after end",
+			data:    replayRequestData(11, 10, 0),
+			wantErr: true,
+		},
+		{
+			name:    "too short",
+			data:    replayRequestData(1, 2, 0)[:8],
+			wantErr: true,
+		},
+		{
+			name:    "too long",
+			data:    append(replayRequestData(1, 2, 0), 0),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, err := parseVisualizerReplayRequest(tt.data)
+			if tt.wantErr {
+				if !errors.Is(err, common.ErrInvalidParameter) {
+					t.Fatalf("expected an invalid parameter error, got %v", err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if *request != *tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, request)
+			}
+		})
+	}
+}
+
+func TestReplayMilestoneCone(t *testing.T) {
+	parentID, parent := testBlock(1, iotago.EmptyBlockID())
+	childID, child := testBlock(2, parentID)
+
+	server := &testINXServer{
+		blocks: map[iotago.BlockID]*iotago.Block{parentID: parent, childID: child},
+		milestones: map[uint32]*iotago.Milestone{
+			10: {Index: 10, Timestamp: 1000, Parents: iotago.BlockIDs{childID}},
+			11: {Index: 11, Timestamp: 1010, Parents: iotago.BlockIDs{childID}},
+		},
+		cones: map[uint32][]*inx.BlockMetadata{
+			10: {
+				testConeBlock(parentID, 10, inx.BlockMetadata_LEDGER_INCLUSION_STATE_NO_TRANSACTION, inx.BlockMetadata_CONFLICT_REASON_NONE),
+				testConeBlock(childID, 10, inx.BlockMetadata_LEDGER_INCLUSION_STATE_CONFLICTING, inx.BlockMetadata_CONFLICT_REASON_INPUT_NOT_FOUND),
+			},
+			11: {
+				testConeBlock(parentID, 11, inx.BlockMetadata_LEDGER_INCLUSION_STATE_NO_TRANSACTION, inx.BlockMetadata_CONFLICT_REASON_NONE),
+			},
+		},
+		coneErrors: map[uint32]error{
+			11: status.Error(codes.Unavailable, "stream broken"),
+		},
+	}
+	d := New(nil, nil, newTestNodeBridge(t, server), nil)
+
+	tests := []struct {
+		name         string
+		index        uint32
+		wantVertices []string
+		wantExcluded []string
+		wantErr      bool
+	}{
+		{
+			name:         "complete cone",
+			index:        10,
+			wantVertices: []string{parentID.ToHex(), childID.ToHex()},
+			wantExcluded: shortIDs(iotago.BlockIDs{childID}),
+		},
+		{
+			name:    "truncated cone",
+			index:   11,
+			wantErr: true,
+		},
+		{
+			name:    "unknown milestone",
+			index:   12,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var msgs []*Msg
+			err := d.replayMilestoneCone(context.Background(), tt.index, 0, func(msg *Msg) error {
+				msgs = append(msgs, msg)
+
+				return nil
+			})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				if len(msgs) != 0 {
+					t.Errorf("expected nothing to be replayed, got %d messages", len(msgs))
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(msgs) != len(tt.wantVertices)+1 {
+				t.Fatalf("expected %d messages, got %d", len(tt.wantVertices)+1, len(msgs))
+			}
+			for i, blockID := range tt.wantVertices {
+				//nolint:forcetypeassert // the type is checked by the test
+				if vertex := msgs[i].Data.(*VisualizerVertex); msgs[i].Type != MsgTypeVisualizerReplayVertex || vertex.ID != blockID {
+					t.Errorf("message %d: expected vertex %s, got %+v", i, blockID, msgs[i])
+				}
+			}
+
+			confirmation, ok := msgs[len(msgs)-1].Data.(*VisualizerReplayConfirmationInfo)
+			if !ok {
+				t.Fatalf("expected the confirmation info last, got %+v", msgs[len(msgs)-1])
+			}
+			if confirmation.Index != tt.index || len(confirmation.ExcludedIDs) != len(tt.wantExcluded) || confirmation.ExcludedIDs[0] != tt.wantExcluded[0] {
+				t.Errorf("unexpected confirmation info %+v", confirmation)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+// generateBlocks creates a chain of blocks where every block references the previous blocks.
+func generateBlocks(count int) []*iotago.Block {
+	blocks := make([]*iotago.Block, count)
+	blockIDs := make([]iotago.BlockID, count)
+
+	for i := 0; i < count; i++ {
+		parents := iotago.BlockIDs{}
+		for j := i - 1; j >= 0 && j >= i-4; j-- {
+			parents = append(parents, blockIDs[j])
+		}
+		if len(parents) == 0 {
+			parents = append(parents, iotago.EmptyBlockID())
+		}
+
+		blocks[i] = &iotago.Block{
+			ProtocolVersion: 2,
+			Parents:         parents,
+			Nonce:           uint64(i),
+		}
+		blockIDs[i] = blocks[i].MustID()
+	}
+
+	return blocks
+}
+
+// BenchmarkVisualizerAddVertex measures the time needed to add one second worth of blocks
+// to a visualizer that is already filled to its capacity.
+func BenchmarkVisualizerAddVertex(b *testing.B) {
+	const capacity = 3000
+
+	for _, bps := range []int{100, 1000} {
+		b.Run(fmt.Sprintf("%dBPS", bps), func(b *testing.B) {
+			blocks := generateBlocks(capacity + bps*10)
+
+			visualizer := NewVisualizer(nil, nil, capacity, 0)
+			for _, block := range blocks[:capacity] {
+				visualizer.AddVertex(block)
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			next := capacity
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < bps; j++ {
+					if next == len(blocks) {
+						next = 0
+					}
+					visualizer.AddVertex(blocks[next])
+					next++
+				}
+			}
+		})
+	}
+}
+
+func TestVisualizerLingerPeriod(t *testing.T) {
+	const lingerPeriod = 50 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// expectState checks the state of the visualizer after waiting for the given time.
+	expectState := func(t *testing.T, visualizer *Visualizer, wait time.Duration, wantActive bool, wantVertices int) {
+		t.Helper()
+
+		time.Sleep(wait)
+
+		if visualizer.IsActive() != wantActive {
+			t.Fatalf("expected active to be %t", wantActive)
+		}
+		if vertices := len(visualizer.Snapshot().Vertices); vertices != wantVertices {
+			t.Fatalf("expected %d vertices, got %d", wantVertices, vertices)
+		}
+	}
+
+	t.Run("resubscribe within the linger period", func(t *testing.T) {
+		visualizer := NewVisualizer(nil, newTestNodeBridge(t, &testINXServer{}), 10, lingerPeriod)
+		visualizer.Run(ctx)
+
+		visualizer.UpdateState(true)
+		for _, block := range generateBlocks(3) {
+			visualizer.AddVertex(block)
+		}
+
+		// the window is kept while the visualizer lingers
+		visualizer.UpdateState(false)
+		expectState(t, visualizer, 0, true, 3)
+
+		// the pending deactivation is canceled by the returning subscriber
+		visualizer.UpdateState(true)
+		expectState(t, visualizer, 2*lingerPeriod, true, 3)
+
+		// the linger period starts again once the subscriber left
+		visualizer.UpdateState(false)
+		expectState(t, visualizer, lingerPeriod/2, true, 3)
+		expectState(t, visualizer, 2*lingerPeriod, false, 0)
+
+		// a new subscriber starts with an empty window
+		visualizer.UpdateState(true)
+		expectState(t, visualizer, 0, true, 0)
+	})
+
+	t.Run("without linger period", func(t *testing.T) {
+		visualizer := NewVisualizer(nil, newTestNodeBridge(t, &testINXServer{}), 10, 0)
+		visualizer.Run(ctx)
+
+		visualizer.UpdateState(true)
+		for _, block := range generateBlocks(3) {
+			visualizer.AddVertex(block)
+		}
+
+		visualizer.UpdateState(false)
+		expectState(t, visualizer, 0, false, 0)
+	})
+}
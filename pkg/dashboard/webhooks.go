@@ -0,0 +1,151 @@
+package dashboard
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/inx-dashboard/pkg/daemon"
+)
+
+const (
+	NodeEventSynced           = "node-synced"
+	NodeEventUnsynced         = "node-unsynced"
+	NodeEventHealthy          = "node-healthy"
+	NodeEventUnhealthy        = "node-unhealthy"
+	NodeEventPeerDisconnected = "peer-disconnected"
+
+	// webhooksQueueSize is the amount of webhooks that can be queued for delivery.
+	webhooksQueueSize = 100
+)
+
+// queuedWebhook is a payload waiting for delivery.
+type queuedWebhook struct {
+	// name describes the payload in the logs.
+	name    string
+	payload any
+}
+
+// NodeEvent represents a state transition of the node that is delivered via webhooks.
+type NodeEvent struct {
+	Type      string `json:"type"`
+	Timestamp int64  `json:"ts"`
+	PeerID    string `json:"peerId,omitempty"`
+	PeerAlias string `json:"peerAlias,omitempty"`
+}
+
+// nodeStateTracker detects state transitions of the node.
+type nodeStateTracker struct {
+	sync.Mutex
+
+	initialized bool
+	isSynced    bool
+	isHealthy   bool
+}
+
+func newNodeStateTracker() *nodeStateTracker {
+	return &nodeStateTracker{}
+}
+
+// update applies the current node status and returns the detected transitions.
+// The first call only initializes the state.
+func (t *nodeStateTracker) update(status *PublicNodeStatus) []*NodeEvent {
+	t.Lock()
+	defer t.Unlock()
+
+	defer func() {
+		t.initialized = true
+		t.isSynced = status.IsSynced
+		t.isHealthy = status.IsHealthy
+	}()
+
+	if !t.initialized {
+		return nil
+	}
+
+	var events []*NodeEvent
+	if t.isSynced != status.IsSynced {
+		eventType := NodeEventUnsynced
+		if status.IsSynced {
+			eventType = NodeEventSynced
+		}
+		events = append(events, newNodeEvent(eventType))
+	}
+
+	if t.isHealthy != status.IsHealthy {
+		eventType := NodeEventUnhealthy
+		if status.IsHealthy {
+			eventType = NodeEventHealthy
+		}
+		events = append(events, newNodeEvent(eventType))
+	}
+
+	return events
+}
+
+// peerDisconnectedEvents returns a node event for every peer that disconnected.
+func peerDisconnectedEvents(peerEvents []*PeerConnectivityEvent) []*NodeEvent {
+	var events []*NodeEvent
+	for _, peerEvent := range peerEvents {
+		if peerEvent.Connected {
+			continue
+		}
+
+		event := newNodeEvent(NodeEventPeerDisconnected)
+		event.Timestamp = peerEvent.Timestamp
+		event.PeerID = peerEvent.ID
+		event.PeerAlias = peerEvent.Alias
+		events = append(events, event)
+	}
+
+	return events
+}
+
+func newNodeEvent(eventType string) *NodeEvent {
+	return &NodeEvent{
+		Type:      eventType,
+		Timestamp: time.Now().Unix(),
+	}
+}
+
+// queueNodeEvents queues the node events for delivery to the webhook URLs.
+func (d *Dashboard) queueNodeEvents(events []*NodeEvent) {
+	for _, event := range events {
+		d.queueWebhook(event.Type, event)
+	}
+}
+
+// queueWebhook queues the payload for delivery to the webhook URLs.
+// The payload is dropped if the queue is full, the callers must not be blocked by slow endpoints.
+func (d *Dashboard) queueWebhook(name string, payload any) {
+	if !d.webhooksSender.Enabled() {
+		return
+	}
+
+	select {
+	case d.webhooksQueue <- &queuedWebhook{name: name, payload: payload}:
+	default:
+		d.LogWarnf("dropped %s webhook, queue is full", name)
+	}
+}
+
+func (d *Dashboard) runWebhooksDelivery() {
+	if !d.webhooksSender.Enabled() {
+		return
+	}
+
+	if err := d.daemon.BackgroundWorker("Dashboard[Webhooks]", func(ctx context.Context) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case queued := <-d.webhooksQueue:
+				for _, err := range d.webhooksSender.Send(ctx, queued.payload) {
+					d.LogWarnf("failed to send %s webhook: %s", queued.name, err)
+				}
+			}
+		}
+	}, daemon.PriorityStopDashboard); err != nil {
+		d.LogPanicf("failed to start worker: %s", err)
+	}
+}
@@ -0,0 +1,123 @@
+package dashboard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/iota.go/v3/nodeclient"
+)
+
+func TestNodeStateTracker(t *testing.T) {
+	type step struct {
+		synced     bool
+		healthy    bool
+		wantEvents []string
+	}
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "initial state",
+			steps: []step{
+				{synced: false, healthy: false},
+			},
+		},
+		{
+			name: "synced and unsynced",
+			steps: []step{
+				{synced: false, healthy: true},
+				{synced: true, healthy: true, wantEvents: []string{NodeEventSynced}},
+				{synced: false, healthy: true, wantEvents: []string{NodeEventUnsynced}},
+			},
+		},
+		{
+			name: "healthy and unhealthy",
+			steps: []step{
+				{synced: true, healthy: true},
+				{synced: true, healthy: false, wantEvents: []string{NodeEventUnhealthy}},
+				{synced: true, healthy: true, wantEvents: []string{NodeEventHealthy}},
+			},
+		},
+		{
+			name: "both changed",
+			steps: []step{
+				{synced: true, healthy: true},
+				{synced: false, healthy: false, wantEvents: []string{NodeEventUnsynced, NodeEventUnhealthy}},
+			},
+		},
+		{
+			name: "unchanged state",
+			steps: []step{
+				{synced: true, healthy: true},
+				{synced: true, healthy: true},
+				{synced: false, healthy: true, wantEvents: []string{NodeEventUnsynced}},
+				{synced: false, healthy: true},
+				{synced: false, healthy: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := newNodeStateTracker()
+
+			for i, step := range tt.steps {
+				events := tracker.update(&PublicNodeStatus{IsSynced: step.synced, IsHealthy: step.healthy})
+
+				if len(events) != len(step.wantEvents) {
+					t.Fatalf("step %d: expected %d events, got %d", i, len(step.wantEvents), len(events))
+				}
+				for j, event := range events {
+					if event.Type != step.wantEvents[j] {
+						t.Errorf("step %d: expected event %s, got %s", i, step.wantEvents[j], event.Type)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestPeerDisconnectedEvents(t *testing.T) {
+	alias := "alias-b"
+	peerA := testPeerInfo("a", true, 0)
+	peerB := testPeerInfo("b", true, 0)
+	peerB.Alias = &alias
+
+	history := NewPeerHistory()
+	start := time.Unix(1_600_000_000, 0)
+
+	steps := []struct {
+		peers []*nodeclient.PeerResponse
+		// wantPeers are the IDs of the peers a disconnected event is expected for.
+		wantPeers []string
+	}{
+		// connected peers are not reported
+		{peers: []*nodeclient.PeerResponse{peerA, peerB}},
+		// the peer was dropped by the node
+		{peers: []*nodeclient.PeerResponse{peerA}, wantPeers: []string{"b"}},
+		// the peer is still missing, it is not reported again
+		{peers: []*nodeclient.PeerResponse{peerA}},
+		// the peer is known but not connected
+		{peers: []*nodeclient.PeerResponse{testPeerInfo("a", false, 0)}, wantPeers: []string{"a"}},
+		{peers: []*nodeclient.PeerResponse{testPeerInfo("a", false, 0)}},
+	}
+
+	for i, step := range steps {
+		now := start.Add(time.Duration(i) * PeerHistoryInterval)
+		events := peerDisconnectedEvents(history.Update(step.peers, now))
+
+		if len(events) != len(step.wantPeers) {
+			t.Fatalf("step %d: expected %d events, got %d", i, len(step.wantPeers), len(events))
+		}
+		for j, event := range events {
+			if event.Type != NodeEventPeerDisconnected || event.PeerID != step.wantPeers[j] || event.Timestamp != now.Unix() {
+				t.Errorf("step %d: expected a disconnect of peer %s at %d, got %+v", i, step.wantPeers[j], now.Unix(), event)
+			}
+			if event.PeerID == "b" && event.PeerAlias != alias {
+				t.Errorf("step %d: expected the alias %s, got %s", i, alias, event.PeerAlias)
+			}
+		}
+	}
+}
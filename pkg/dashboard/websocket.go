@@ -2,14 +2,18 @@ package dashboard
 
 import (
 	"context"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"golang.org/x/time/rate"
 	"nhooyr.io/websocket"
 
 	"github.com/iotaledger/hive.go/runtime/syncutils"
 	"github.com/iotaledger/hive.go/web/websockethub"
 	"github.com/iotaledger/inx-dashboard/pkg/jwt"
+	iotago "github.com/iotaledger/iota.go/v3"
 )
 
 var (
@@ -45,8 +49,99 @@ const (
 	MsgTypeVisualizerTipInfo
 	// MsgTypeDatabaseSizeMetric is the type of the database Size message for the metrics.
 	MsgTypeDatabaseSizeMetric
+	// MsgTypeMilestoneDetails is the type of the MilestoneDetails message.
+	MsgTypeMilestoneDetails
+	// MsgTypeAlert is the type of the Alert message.
+	MsgTypeAlert
+	// MsgTypePeerHistory is the type of the PeerConnectivityEvent message.
+	MsgTypePeerHistory
+	// MsgTypeTangleAnalytics is the type of the TangleAnalytics message.
+	MsgTypeTangleAnalytics
+	// MsgTypeVisualizerReplayVertex is the type of the Vertex message for the visualizer replay.
+	MsgTypeVisualizerReplayVertex
+	// MsgTypeVisualizerReplayConfirmedInfo is the type of the ConfirmedInfo message for the visualizer replay.
+	MsgTypeVisualizerReplayConfirmedInfo
+	// MsgTypeVisualizerReplayStatus is the type of the ReplayStatus message for the visualizer replay.
+	MsgTypeVisualizerReplayStatus
+	// MsgTypeVisualizerHighlight is the type of the Highlight message for the visualizer.
+	MsgTypeVisualizerHighlight
+	// MsgTypeConflict is the type of the ConflictingBlock message.
+	MsgTypeConflict
+	// MsgTypeBlockStatus is the type of the BlockStatus message of watched blocks.
+	// The topic is protected, watched blocks are queried in the node with every confirmed milestone.
+	MsgTypeBlockStatus
+
+	// msgTypeCount is the number of message types, new types are added above.
+	msgTypeCount
 )
 
+// websocketPublicTopics are the topics which can be registered without authorization.
+var websocketPublicTopics = []WebSocketMsgType{
+	MsgTypeSyncStatus,
+	MsgTypePublicNodeStatus,
+	MsgTypeGossipMetrics,
+	MsgTypeMilestone,
+	MsgTypeConfirmedMsMetrics,
+	MsgTypeVisualizerVertex,
+	MsgTypeVisualizerSolidInfo,
+	MsgTypeVisualizerConfirmedInfo,
+	MsgTypeVisualizerMilestoneInfo,
+	MsgTypeVisualizerTipInfo,
+	MsgTypeMilestoneDetails,
+	MsgTypeTangleAnalytics,
+	MsgTypeVisualizerHighlight,
+	MsgTypeConflict,
+}
+
+// latestRequest runs a request of a websocket client in the background.
+// Starting a request cancels the running one, so that only the latest request of the client is answered.
+type latestRequest struct {
+	sync.Mutex
+
+	cancel context.CancelFunc
+}
+
+// start cancels the running request and runs the new one with a context derived from the given one.
+func (r *latestRequest) start(ctx context.Context, request func(ctx context.Context)) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.stopWithoutLocking()
+
+	ctxRequest, ctxRequestCancel := context.WithCancel(ctx)
+	r.cancel = ctxRequestCancel
+
+	go func() {
+		defer ctxRequestCancel()
+		request(ctxRequest)
+	}()
+}
+
+// stop cancels the running request.
+func (r *latestRequest) stop() {
+	r.Lock()
+	defer r.Unlock()
+
+	r.stopWithoutLocking()
+}
+
+func (r *latestRequest) stopWithoutLocking() {
+	if r.cancel != nil {
+		r.cancel()
+		r.cancel = nil
+	}
+}
+
+func isProtectedTopic(topic WebSocketMsgType) bool {
+	for _, publicTopic := range websocketPublicTopics {
+		if topic == publicTopic {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (d *Dashboard) websocketRoute(ctx echo.Context) error {
 	defer func() {
 		if r := recover(); r != nil {
@@ -54,27 +149,23 @@ func (d *Dashboard) websocketRoute(ctx echo.Context) error {
 		}
 	}()
 
-	publicTopics := []WebSocketMsgType{
-		MsgTypeSyncStatus,
-		MsgTypePublicNodeStatus,
-		MsgTypeGossipMetrics,
-		MsgTypeMilestone,
-		MsgTypeConfirmedMsMetrics,
-		MsgTypeVisualizerVertex,
-		MsgTypeVisualizerSolidInfo,
-		MsgTypeVisualizerConfirmedInfo,
-		MsgTypeVisualizerMilestoneInfo,
-		MsgTypeVisualizerTipInfo,
-	}
+	topicsLock := syncutils.RWMutex{}
+	registeredTopics := make(map[WebSocketMsgType]struct{})
+	initValuesSent := make(map[WebSocketMsgType]struct{})
+	vertexTagFilter := ""
+	// only the latest replay and highlight request of the client are answered
+	replayRequest := &latestRequest{}
+	highlightRequest := &latestRequest{}
 
-	isProtectedTopic := func(topic WebSocketMsgType) bool {
-		for _, publicTopic := range publicTopics {
-			if topic == publicTopic {
-				return false
-			}
-		}
+	// highlight requests may cause lookups in the node, therefore they are limited per client
+	highlightLimiter := rate.NewLimiter(rate.Every(VisualizerHighlightInterval), VisualizerHighlightBurst)
 
-		return true
+	// matchesVertexTagFilter checks whether the vertex matches the tag prefix filter of the client
+	matchesVertexTagFilter := func(vertex *VisualizerVertex) bool {
+		topicsLock.RLock()
+		defer topicsLock.RUnlock()
+
+		return vertexTagFilter == "" || strings.HasPrefix(vertex.Tag, vertexTagFilter)
 	}
 
 	// this function sends the initial values for some topics
@@ -98,15 +189,7 @@ func (d *Dashboard) websocketRoute(ctx echo.Context) error {
 			_ = client.Send(ctxMsg, &Msg{Type: MsgTypeSyncStatus, Data: d.getSyncStatus()})
 
 		case MsgTypePublicNodeStatus:
-			nodeInfo, err := d.getNodeInfo(ctxNodeInfos)
-			if err != nil {
-				d.LogWarnf("failed to get node info: %s", err)
-
-				return
-			}
-
-			data := getPublicNodeStatusByNodeInfo(nodeInfo, d.nodeBridge.IsNodeAlmostSynced())
-			_ = d.hub.BroadcastMsg(ctxMsg, &Msg{Type: MsgTypePublicNodeStatus, Data: data})
+			_ = client.Send(ctxMsg, &Msg{Type: MsgTypePublicNodeStatus, Data: d.getPublicNodeStatus()})
 
 		case MsgTypeNodeInfoExtended:
 			data, err := d.getNodeInfoExtended(ctxNodeInfos)
@@ -129,8 +212,8 @@ func (d *Dashboard) websocketRoute(ctx echo.Context) error {
 		case MsgTypeMilestone:
 			start := d.getLatestMilestoneIndex()
 			for msIndex := start - 10; msIndex <= start; msIndex++ {
-				if milestoneIDHex, err := d.getMilestoneIDHex(ctxNodeInfos, msIndex); err == nil {
-					_ = client.Send(ctxMsg, &Msg{Type: MsgTypeMilestone, Data: &Milestone{MilestoneID: milestoneIDHex, Index: msIndex}})
+				if milestone, err := d.getMilestone(ctxNodeInfos, msIndex); err == nil {
+					_ = client.Send(ctxMsg, &Msg{Type: MsgTypeMilestone, Data: milestone})
 				} else {
 					d.LogWarnf("failed to get milestone %d: %s", msIndex, err)
 
@@ -158,21 +241,46 @@ func (d *Dashboard) websocketRoute(ctx echo.Context) error {
 
 		case MsgTypeVisualizerVertex:
 			d.visualizer.ForEachCreated(func(vertex *VisualizerVertex) bool {
+				if !matchesVertexTagFilter(vertex) {
+					return true
+				}
+
 				// don't drop the messages to fill the visualizer without missing any vertex
 				_ = client.Send(ctxMsg, &Msg{Type: MsgTypeVisualizerVertex, Data: vertex}, true)
 
 				return true
-			}, VisualizerInitValuesCount)
+			}, d.visualizerInitValues)
 
 		case MsgTypeDatabaseSizeMetric:
-			_ = client.Send(ctxMsg, &Msg{Type: MsgTypeDatabaseSizeMetric, Data: d.cachedDatabaseSizeMetrics})
+			_ = client.Send(ctxMsg, &Msg{Type: MsgTypeDatabaseSizeMetric, Data: d.getCachedDatabaseSizeMetrics()})
+
+		case MsgTypeTangleAnalytics:
+			_ = client.Send(ctxMsg, &Msg{Type: MsgTypeTangleAnalytics, Data: d.TangleAnalytics()})
+
+		case MsgTypePeerHistory:
+			for _, event := range d.peerHistory.Events() {
+				_ = client.Send(ctxMsg, &Msg{Type: MsgTypePeerHistory, Data: event})
+			}
+
+		case MsgTypeAlert:
+			if d.alertsEnabled {
+				for _, alert := range d.alertEngine.Alerts() {
+					_ = client.Send(ctxMsg, &Msg{Type: MsgTypeAlert, Data: alert})
+				}
+			}
+
+		case MsgTypeConflict:
+			for _, conflict := range d.conflictLog.Recent(ConflictInitValues) {
+				_ = client.Send(ctxMsg, &Msg{Type: MsgTypeConflict, Data: conflict})
+			}
+
+		case MsgTypeMilestoneDetails:
+			for _, details := range d.milestoneDetails.Recent() {
+				_ = client.Send(ctxMsg, &Msg{Type: MsgTypeMilestoneDetails, Data: details})
+			}
 		}
 	}
 
-	topicsLock := syncutils.RWMutex{}
-	registeredTopics := make(map[WebSocketMsgType]struct{})
-	initValuesSent := make(map[WebSocketMsgType]struct{})
-
 	return d.hub.ServeWebsocket(ctx.Response(), ctx.Request(),
 		// onCreate gets called when the client is created
 		func(client *websockethub.Client) {
@@ -186,11 +294,21 @@ func (d *Dashboard) websocketRoute(ctx echo.Context) error {
 				_, registered := registeredTopics[msg.Type]
 				topicsLock.RUnlock()
 
+				if registered && msg.Type == MsgTypeVisualizerVertex {
+					if vertex, ok := msg.Data.(*VisualizerVertex); ok {
+						return matchesVertexTagFilter(vertex)
+					}
+				}
+
 				return registered
 			}
 			client.ReceiveChan = make(chan *websockethub.WebsocketMsg, 100)
 
 			go func() {
+				// stop the replay and the highlight request if the client was disconnected
+				defer replayRequest.stop()
+				defer highlightRequest.stop()
+
 				for {
 					// we need to nest the client.ReceiveChan into the default case because
 					// the select cases are executed in random order if multiple
@@ -252,6 +370,120 @@ func (d *Dashboard) websocketRoute(ctx echo.Context) error {
 									topicsLock.Lock()
 									delete(registeredTopics, topic)
 									topicsLock.Unlock()
+
+									if topic == MsgTypeVisualizerReplayVertex {
+										replayRequest.stop()
+									}
+
+									if topic == MsgTypeVisualizerHighlight {
+										highlightRequest.stop()
+									}
+
+									if topic == MsgTypeBlockStatus {
+										d.blockWatcher.Unwatch(client.ID())
+									}
+
+								} else if cmd == WebsocketCmdFilter {
+
+									// only the vertices of the visualizer can be filtered by tag
+									if topic != MsgTypeVisualizerVertex {
+										continue
+									}
+
+									topicsLock.Lock()
+									vertexTagFilter = strings.ToLower(string(msg.Data[2:]))
+									topicsLock.Unlock()
+
+								} else if cmd == WebsocketCmdReplay {
+
+									// replays are only allowed for clients that registered the (protected) replay topic
+									if topic != MsgTypeVisualizerReplayVertex {
+										continue
+									}
+
+									topicsLock.RLock()
+									_, registered := registeredTopics[MsgTypeVisualizerReplayVertex]
+									topicsLock.RUnlock()
+									if !registered {
+										continue
+									}
+
+									request, err := parseVisualizerReplayRequest(msg.Data[2:])
+									if err != nil {
+										d.LogDebugf("invalid visualizer replay request: %s", err)
+
+										continue
+									}
+
+									replayRequest.start(client.Context(), func(ctx context.Context) {
+										d.replayMilestoneCones(ctx, client, request)
+									})
+
+								} else if cmd == WebsocketCmdHighlight {
+
+									// the highlight set is only sent to clients that registered the highlight topic
+									if topic != MsgTypeVisualizerHighlight {
+										continue
+									}
+
+									topicsLock.RLock()
+									_, registered := registeredTopics[MsgTypeVisualizerHighlight]
+									topicsLock.RUnlock()
+									if !registered {
+										continue
+									}
+
+									blockID, err := iotago.BlockIDFromHexString(string(msg.Data[2:]))
+									if err != nil {
+										d.LogDebugf("invalid visualizer highlight request: %s", err)
+
+										continue
+									}
+
+									if !highlightLimiter.Allow() {
+										d.LogDebugf("dropped visualizer highlight request of block %s, rate limit exceeded", blockID.ToHex())
+
+										continue
+									}
+
+									highlightRequest.start(client.Context(), func(ctx context.Context) {
+										highlight, err := d.getVisualizerHighlight(ctx, blockID)
+										if err != nil {
+											d.LogDebugf("failed to trace the cones of block %s: %s", blockID.ToHex(), err)
+
+											return
+										}
+
+										ctxMsg, ctxMsgCancel := context.WithTimeout(ctx, d.websocketWriteTimeout)
+										defer ctxMsgCancel()
+
+										_ = client.Send(ctxMsg, &Msg{Type: MsgTypeVisualizerHighlight, Data: highlight}, true)
+									})
+
+								} else if cmd == WebsocketCmdWatch {
+
+									// status changes are only sent to clients that registered the block status topic
+									if topic != MsgTypeBlockStatus {
+										continue
+									}
+
+									topicsLock.RLock()
+									_, registered := registeredTopics[MsgTypeBlockStatus]
+									topicsLock.RUnlock()
+									if !registered {
+										continue
+									}
+
+									blockIDs, err := parseBlockIDs(string(msg.Data[2:]))
+									if err != nil {
+										d.LogDebugf("invalid watch request: %s", err)
+
+										continue
+									}
+
+									if err := d.watchBlocks(client, blockIDs); err != nil {
+										d.LogDebugf("rejected watch request: %s", err)
+									}
 								}
 							}
 						}
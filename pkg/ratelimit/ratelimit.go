@@ -0,0 +1,130 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Budget defines a token bucket that is tracked separately for every client.
+type Budget struct {
+	sync.Mutex
+
+	// Name identifies the budget in the metrics.
+	Name string
+
+	limit      rate.Limit
+	burst      int
+	expiresIn  time.Duration
+	maxClients int
+
+	clients     map[string]*client
+	lastCleanup time.Time
+	// overflow is shared by the clients that are not tracked because the maximum amount of clients is reached.
+	overflow *client
+
+	allowed    uint64
+	rejected   uint64
+	overflowed uint64
+}
+
+type client struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// Metrics holds the state of a budget.
+type Metrics struct {
+	Name     string
+	Allowed  uint64
+	Rejected uint64
+	// Overflowed is the amount of requests of clients that were not tracked separately.
+	Overflowed uint64
+	Clients    int
+}
+
+// NewBudget creates a budget that refills maxRequests tokens per period and holds up to maxBurst tokens per client.
+// Clients that were not seen for expiresIn are forgotten.
+// At most maxClients clients are tracked separately, further clients share a single token bucket until tracked clients expire.
+func NewBudget(name string, maxRequests int, period time.Duration, maxBurst int, expiresIn time.Duration, maxClients int) *Budget {
+	limit := rate.Limit(float64(maxRequests) / period.Seconds())
+
+	return &Budget{
+		Name:       name,
+		limit:      limit,
+		burst:      maxBurst,
+		expiresIn:  expiresIn,
+		maxClients: maxClients,
+		clients:    make(map[string]*client),
+		overflow:   &client{limiter: rate.NewLimiter(limit, maxBurst)},
+	}
+}
+
+// Allow consumes a token of the client.
+// If no token is available, it returns false and the time after which the next token is available.
+func (b *Budget) Allow(clientID string) (bool, time.Duration) {
+	b.Lock()
+	defer b.Unlock()
+
+	now := time.Now()
+	b.cleanup(now)
+
+	c, exists := b.clients[clientID]
+	if !exists {
+		if len(b.clients) >= b.maxClients {
+			c = b.overflow
+			b.overflowed++
+		} else {
+			c = &client{limiter: rate.NewLimiter(b.limit, b.burst)}
+			b.clients[clientID] = c
+		}
+	}
+	c.lastSeen = now
+
+	reservation := c.limiter.ReserveN(now, 1)
+	if !reservation.OK() {
+		b.rejected++
+
+		return false, b.expiresIn
+	}
+
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		// give the token back, the request is rejected
+		reservation.CancelAt(now)
+		b.rejected++
+
+		return false, delay
+	}
+	b.allowed++
+
+	return true, 0
+}
+
+// cleanup removes the clients that were not seen for the expiry time.
+func (b *Budget) cleanup(now time.Time) {
+	if now.Sub(b.lastCleanup) < b.expiresIn {
+		return
+	}
+	b.lastCleanup = now
+
+	for clientID, c := range b.clients {
+		if now.Sub(c.lastSeen) > b.expiresIn {
+			delete(b.clients, clientID)
+		}
+	}
+}
+
+// Metrics returns the current state of the budget.
+func (b *Budget) Metrics() *Metrics {
+	b.Lock()
+	defer b.Unlock()
+
+	return &Metrics{
+		Name:       b.Name,
+		Allowed:    b.allowed,
+		Rejected:   b.rejected,
+		Overflowed: b.overflowed,
+		Clients:    len(b.clients),
+	}
+}
@@ -0,0 +1,105 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBudgetAllow(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxRequests  int
+		maxBurst     int
+		maxClients   int
+		clients      []string
+		wantAllowed  []bool
+		wantClients  int
+		wantOverflow uint64
+	}{
+		{
+			name:        "burst of a single client",
+			maxRequests: 1,
+			maxBurst:    2,
+			maxClients:  10,
+			clients:     []string{"a", "a", "a"},
+			wantAllowed: []bool{true, true, false},
+			wantClients: 1,
+		},
+		{
+			name:        "clients have separate budgets",
+			maxRequests: 1,
+			maxBurst:    1,
+			maxClients:  10,
+			clients:     []string{"a", "b", "a", "b"},
+			wantAllowed: []bool{true, true, false, false},
+			wantClients: 2,
+		},
+		{
+			name:         "untracked clients share a budget",
+			maxRequests:  1,
+			maxBurst:     1,
+			maxClients:   1,
+			clients:      []string{"a", "b", "c", "a"},
+			wantAllowed:  []bool{true, true, false, false},
+			wantClients:  1,
+			wantOverflow: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			budget := NewBudget("test", tt.maxRequests, time.Hour, tt.maxBurst, time.Hour, tt.maxClients)
+
+			var wantRejected uint64
+			for i, clientID := range tt.clients {
+				allowed, retryAfter := budget.Allow(clientID)
+				if allowed != tt.wantAllowed[i] {
+					t.Errorf("request %d of client %s: expected allowed %v, got %v", i, clientID, tt.wantAllowed[i], allowed)
+				}
+				if !allowed {
+					wantRejected++
+					if retryAfter <= 0 {
+						t.Errorf("request %d of client %s: expected a positive retry after, got %v", i, clientID, retryAfter)
+					}
+				}
+			}
+
+			metrics := budget.Metrics()
+			if metrics.Clients != tt.wantClients {
+				t.Errorf("expected %d clients, got %d", tt.wantClients, metrics.Clients)
+			}
+			if metrics.Rejected != wantRejected {
+				t.Errorf("expected %d rejected requests, got %d", wantRejected, metrics.Rejected)
+			}
+			if metrics.Allowed != uint64(len(tt.clients))-wantRejected {
+				t.Errorf("expected %d allowed requests, got %d", uint64(len(tt.clients))-wantRejected, metrics.Allowed)
+			}
+			if metrics.Overflowed != tt.wantOverflow {
+				t.Errorf("expected %d overflowed requests, got %d", tt.wantOverflow, metrics.Overflowed)
+			}
+		})
+	}
+}
+
+func TestBudgetForgetsExpiredClients(t *testing.T) {
+	budget := NewBudget("test", 1, time.Hour, 1, 10*time.Millisecond, 1)
+
+	if allowed, _ := budget.Allow("a"); !allowed {
+		t.Fatal("expected the first request to be allowed")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	// the expired client makes room for a new one
+	if allowed, _ := budget.Allow("b"); !allowed {
+		t.Fatal("expected the request of a new client to be allowed")
+	}
+
+	metrics := budget.Metrics()
+	if metrics.Clients != 1 {
+		t.Errorf("expected 1 client, got %d", metrics.Clients)
+	}
+	if metrics.Overflowed != 0 {
+		t.Errorf("expected no overflowed requests, got %d", metrics.Overflowed)
+	}
+}
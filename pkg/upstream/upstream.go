@@ -0,0 +1,215 @@
+package upstream
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/iota.go/v3/nodeclient"
+)
+
+// State is the state of the circuit breaker of an endpoint.
+type State int
+
+const (
+	// StateClosed means the endpoint is healthy and receives requests.
+	StateClosed State = iota
+	// StateOpen means the endpoint failed and does not receive requests until the open period elapsed.
+	StateOpen
+	// StateHalfOpen means the open period elapsed and the next request decides whether the circuit closes or opens again.
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// Endpoint is a node REST API guarded by a circuit breaker.
+type Endpoint struct {
+	// Name identifies the endpoint in logs.
+	Name   string
+	Client *nodeclient.Client
+
+	pool     *Pool
+	state    State
+	failures int
+	openedAt time.Time
+}
+
+// Pool holds the primary endpoint and the failover endpoints in the order they are tried.
+type Pool struct {
+	sync.Mutex
+
+	endpoints        []*Endpoint
+	failureThreshold int
+	openPeriod       time.Duration
+	onStateChanged   func(endpoint *Endpoint, state State)
+}
+
+// NewPool creates a pool with the primary endpoint.
+// The circuit of an endpoint opens after failureThreshold consecutive failures and stays open for openPeriod.
+// onStateChanged is called (under the lock of the pool) whenever the circuit of an endpoint changes its state, it may be nil.
+func NewPool(name string, primary *nodeclient.Client, failureThreshold int, openPeriod time.Duration, onStateChanged func(endpoint *Endpoint, state State)) *Pool {
+	if failureThreshold < 1 {
+		failureThreshold = 1
+	}
+
+	p := &Pool{
+		failureThreshold: failureThreshold,
+		openPeriod:       openPeriod,
+		onStateChanged:   onStateChanged,
+	}
+	p.Add(name, primary)
+
+	return p
+}
+
+// Add adds a failover endpoint, which is tried after all previously added endpoints.
+func (p *Pool) Add(name string, client *nodeclient.Client) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.endpoints = append(p.endpoints, &Endpoint{
+		Name:   name,
+		Client: client,
+		pool:   p,
+	})
+}
+
+// Primary returns the primary endpoint.
+func (p *Pool) Primary() *Endpoint {
+	p.Lock()
+	defer p.Unlock()
+
+	return p.endpoints[0]
+}
+
+// Endpoints returns all endpoints.
+func (p *Pool) Endpoints() []*Endpoint {
+	p.Lock()
+	defer p.Unlock()
+
+	return append(make([]*Endpoint, 0, len(p.endpoints)), p.endpoints...)
+}
+
+// Available returns the endpoints that currently accept requests, in the order they should be tried.
+// If the circuits of all endpoints are open, the primary endpoint is returned anyway.
+func (p *Pool) Available() []*Endpoint {
+	p.Lock()
+	defer p.Unlock()
+
+	now := time.Now()
+
+	available := make([]*Endpoint, 0, len(p.endpoints))
+	for _, endpoint := range p.endpoints {
+		if endpoint.state == StateOpen && now.Sub(endpoint.openedAt) >= p.openPeriod {
+			endpoint.setState(StateHalfOpen)
+		}
+
+		if endpoint.state != StateOpen {
+			available = append(available, endpoint)
+		}
+	}
+
+	if len(available) == 0 {
+		available = append(available, p.endpoints[0])
+	}
+
+	return available
+}
+
+// CheckHealth queries the health of all endpoints.
+// Healthy endpoints are closed, unhealthy or unreachable endpoints are opened.
+func (p *Pool) CheckHealth(ctx context.Context, timeout time.Duration) {
+	for _, endpoint := range p.Endpoints() {
+		ctxHealth, ctxHealthCancel := context.WithTimeout(ctx, timeout)
+		healthy, err := endpoint.Client.Health(ctxHealth)
+		ctxHealthCancel()
+
+		if ctx.Err() != nil {
+			// shutdown
+			return
+		}
+
+		if err != nil || !healthy {
+			endpoint.ReportUnhealthy()
+
+			continue
+		}
+
+		endpoint.ReportSuccess()
+	}
+}
+
+// State returns the state of the circuit breaker.
+func (e *Endpoint) State() State {
+	e.pool.Lock()
+	defer e.pool.Unlock()
+
+	return e.state
+}
+
+// IsPrimary returns whether the endpoint is the primary endpoint of the pool.
+func (e *Endpoint) IsPrimary() bool {
+	e.pool.Lock()
+	defer e.pool.Unlock()
+
+	return e.pool.endpoints[0] == e
+}
+
+// ReportSuccess closes the circuit.
+func (e *Endpoint) ReportSuccess() {
+	e.pool.Lock()
+	defer e.pool.Unlock()
+
+	e.failures = 0
+	e.setState(StateClosed)
+}
+
+// ReportFailure counts a failed request. The circuit opens if the failure threshold is reached,
+// or immediately if the circuit was half-open.
+func (e *Endpoint) ReportFailure() {
+	e.pool.Lock()
+	defer e.pool.Unlock()
+
+	e.failures++
+	if e.state == StateHalfOpen || e.failures >= e.pool.failureThreshold {
+		e.open()
+	}
+}
+
+// ReportUnhealthy opens the circuit immediately.
+func (e *Endpoint) ReportUnhealthy() {
+	e.pool.Lock()
+	defer e.pool.Unlock()
+
+	e.open()
+}
+
+func (e *Endpoint) open() {
+	if e.state != StateOpen {
+		// keep the time of the first opening, otherwise a failing endpoint never becomes half-open again
+		e.openedAt = time.Now()
+	}
+	e.setState(StateOpen)
+}
+
+func (e *Endpoint) setState(state State) {
+	if e.state == state {
+		return
+	}
+	e.state = state
+
+	if e.pool.onStateChanged != nil {
+		e.pool.onStateChanged(e, state)
+	}
+}
@@ -0,0 +1,149 @@
+package upstream
+
+import (
+	"testing"
+	"time"
+)
+
+// event is reported to the endpoint with the given index before the available endpoints are checked.
+type event struct {
+	endpoint int
+	report   func(e *Endpoint)
+}
+
+func failure(endpoint int) event {
+	return event{endpoint: endpoint, report: (*Endpoint).ReportFailure}
+}
+
+func success(endpoint int) event {
+	return event{endpoint: endpoint, report: (*Endpoint).ReportSuccess}
+}
+
+func unhealthy(endpoint int) event {
+	return event{endpoint: endpoint, report: (*Endpoint).ReportUnhealthy}
+}
+
+func TestPoolCircuitBreaking(t *testing.T) {
+	tests := []struct {
+		name          string
+		threshold     int
+		openPeriod    time.Duration
+		events        []event
+		wantAvailable []string
+		wantStates    []State
+	}{
+		{
+			name:          "healthy endpoints in order",
+			threshold:     2,
+			openPeriod:    time.Hour,
+			wantAvailable: []string{"primary", "failover"},
+			wantStates:    []State{StateClosed, StateClosed},
+		},
+		{
+			name:          "failures below the threshold",
+			threshold:     2,
+			openPeriod:    time.Hour,
+			events:        []event{failure(0)},
+			wantAvailable: []string{"primary", "failover"},
+			wantStates:    []State{StateClosed, StateClosed},
+		},
+		{
+			name:          "failures reaching the threshold",
+			threshold:     2,
+			openPeriod:    time.Hour,
+			events:        []event{failure(0), failure(0)},
+			wantAvailable: []string{"failover"},
+			wantStates:    []State{StateOpen, StateClosed},
+		},
+		{
+			name:          "success resets the failures",
+			threshold:     2,
+			openPeriod:    time.Hour,
+			events:        []event{failure(0), success(0), failure(0)},
+			wantAvailable: []string{"primary", "failover"},
+			wantStates:    []State{StateClosed, StateClosed},
+		},
+		{
+			name:          "unhealthy opens immediately",
+			threshold:     5,
+			openPeriod:    time.Hour,
+			events:        []event{unhealthy(1)},
+			wantAvailable: []string{"primary"},
+			wantStates:    []State{StateClosed, StateOpen},
+		},
+		{
+			name:          "primary is used if all circuits are open",
+			threshold:     1,
+			openPeriod:    time.Hour,
+			events:        []event{failure(0), failure(1)},
+			wantAvailable: []string{"primary"},
+			wantStates:    []State{StateOpen, StateOpen},
+		},
+		{
+			name:          "half-open after the open period",
+			threshold:     1,
+			openPeriod:    0,
+			events:        []event{failure(0)},
+			wantAvailable: []string{"primary", "failover"},
+			wantStates:    []State{StateHalfOpen, StateClosed},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := NewPool("primary", nil, tt.threshold, tt.openPeriod, nil)
+			pool.Add("failover", nil)
+
+			endpoints := pool.Endpoints()
+			for _, e := range tt.events {
+				e.report(endpoints[e.endpoint])
+			}
+
+			available := pool.Available()
+			if len(available) != len(tt.wantAvailable) {
+				t.Fatalf("expected %d available endpoints, got %d", len(tt.wantAvailable), len(available))
+			}
+			for i, endpoint := range available {
+				if endpoint.Name != tt.wantAvailable[i] {
+					t.Errorf("expected available endpoint %d to be %s, got %s", i, tt.wantAvailable[i], endpoint.Name)
+				}
+			}
+
+			for i, endpoint := range endpoints {
+				if state := endpoint.State(); state != tt.wantStates[i] {
+					t.Errorf("expected endpoint %s to be %s, got %s", endpoint.Name, tt.wantStates[i], state)
+				}
+			}
+		})
+	}
+}
+
+func TestPoolHalfOpenCircuit(t *testing.T) {
+	var changes []State
+	pool := NewPool("primary", nil, 3, 0, func(_ *Endpoint, state State) {
+		changes = append(changes, state)
+	})
+	primary := pool.Primary()
+
+	primary.ReportUnhealthy()
+	pool.Available()
+
+	// a single failure opens a half-open circuit again
+	primary.ReportFailure()
+	pool.Available()
+	primary.ReportSuccess()
+
+	wantChanges := []State{StateOpen, StateHalfOpen, StateOpen, StateHalfOpen, StateClosed}
+	if len(changes) != len(wantChanges) {
+		t.Fatalf("expected state changes %v, got %v", wantChanges, changes)
+	}
+	for i := range changes {
+		if changes[i] != wantChanges[i] {
+			t.Fatalf("expected state changes %v, got %v", wantChanges, changes)
+		}
+	}
+
+	if !primary.IsPrimary() {
+		t.Error("expected the endpoint to be the primary endpoint")
+	}
+}
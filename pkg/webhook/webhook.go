@@ -0,0 +1,160 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/iotaledger/hive.go/runtime/options"
+)
+
+const (
+	// HeaderSignature is the header that contains the HMAC-SHA256 signature of the body.
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Sender delivers JSON payloads to a list of webhook URLs.
+type Sender struct {
+	urls         []string
+	httpClient   *http.Client
+	timeout      time.Duration
+	secret       []byte
+	maxRetries   int
+	retryBackoff time.Duration
+}
+
+// WithHTTPClient sets the HTTP client used to deliver the payloads.
+func WithHTTPClient(httpClient *http.Client) options.Option[Sender] {
+	return func(s *Sender) {
+		s.httpClient = httpClient
+	}
+}
+
+// WithTimeout sets the timeout for a single delivery.
+func WithTimeout(timeout time.Duration) options.Option[Sender] {
+	return func(s *Sender) {
+		s.timeout = timeout
+	}
+}
+
+// WithSecret sets the secret used to sign the payloads.
+// If the secret is empty, the payloads are not signed.
+func WithSecret(secret string) options.Option[Sender] {
+	return func(s *Sender) {
+		s.secret = []byte(secret)
+	}
+}
+
+// WithMaxRetries sets how often a failed delivery is retried.
+func WithMaxRetries(maxRetries int) options.Option[Sender] {
+	return func(s *Sender) {
+		s.maxRetries = maxRetries
+	}
+}
+
+// WithRetryBackoff sets the initial backoff between retries, which is doubled after every retry.
+func WithRetryBackoff(retryBackoff time.Duration) options.Option[Sender] {
+	return func(s *Sender) {
+		s.retryBackoff = retryBackoff
+	}
+}
+
+// NewSender creates a new webhook sender for the given URLs.
+func NewSender(urls []string, opts ...options.Option[Sender]) *Sender {
+	return options.Apply(&Sender{
+		urls:         urls,
+		httpClient:   http.DefaultClient,
+		timeout:      5 * time.Second,
+		maxRetries:   0,
+		retryBackoff: 1 * time.Second,
+	}, opts)
+}
+
+// Enabled returns whether there are any URLs configured.
+func (s *Sender) Enabled() bool {
+	return len(s.urls) > 0
+}
+
+// Send posts the JSON encoded payload to all configured URLs.
+// It returns the errors of all failed deliveries.
+func (s *Sender) Send(ctx context.Context, payload interface{}) []error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return []error{fmt.Errorf("unable to marshal webhook payload: %w", err)}
+	}
+
+	var errs []error
+	for _, url := range s.urls {
+		if err := s.postWithRetry(ctx, url, body); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// Signature returns the hex encoded HMAC-SHA256 signature of the body, prefixed with the algorithm.
+func Signature(secret []byte, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *Sender) postWithRetry(ctx context.Context, url string, body []byte) error {
+	backoff := s.retryBackoff
+
+	var err error
+	for attempt := 0; attempt <= s.maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		if err = s.post(ctx, url, body); err == nil {
+			return nil
+		}
+	}
+
+	return err
+}
+
+func (s *Sender) post(ctx context.Context, url string, body []byte) error {
+	ctxRequest, ctxRequestCancel := context.WithTimeout(ctx, s.timeout)
+	defer ctxRequestCancel()
+
+	req, err := http.NewRequestWithContext(ctxRequest, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("unable to build webhook request for %s: %w", url, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(s.secret) > 0 {
+		req.Header.Set(HeaderSignature, Signature(s.secret, body))
+	}
+
+	res, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook delivery to %s failed: %w", url, err)
+	}
+	defer res.Body.Close()
+
+	// drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("webhook delivery to %s failed: status code %d", url, res.StatusCode)
+	}
+
+	return nil
+}
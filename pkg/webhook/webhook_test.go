@@ -0,0 +1,149 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// webhookStub is a local webhook endpoint that records the received deliveries.
+type webhookStub struct {
+	sync.Mutex
+
+	// failures is the amount of requests that are answered with an error before succeeding.
+	failures   int
+	bodies     [][]byte
+	signatures []string
+	attempts   int
+}
+
+func (s *webhookStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.attempts++
+	if s.attempts <= s.failures {
+		w.WriteHeader(http.StatusServiceUnavailable)
+
+		return
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	s.bodies = append(s.bodies, body)
+	s.signatures = append(s.signatures, r.Header.Get(HeaderSignature))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestSenderSend(t *testing.T) {
+	type payload struct {
+		Type string `json:"type"`
+	}
+
+	tests := []struct {
+		name         string
+		secret       string
+		maxRetries   int
+		failures     int
+		wantErr      bool
+		wantAttempts int
+	}{
+		{name: "unsigned", wantAttempts: 1},
+		{name: "signed", secret: "secret", wantAttempts: 1},
+		{name: "retried until success", maxRetries: 2, failures: 2, wantAttempts: 3},
+		{name: "retries exhausted", maxRetries: 1, failures: 2, wantErr: true, wantAttempts: 2},
+		{name: "no retries", failures: 1, wantErr: true, wantAttempts: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stub := &webhookStub{failures: test.failures}
+			server := httptest.NewServer(stub)
+			defer server.Close()
+
+			sender := NewSender([]string{server.URL},
+				WithSecret(test.secret),
+				WithMaxRetries(test.maxRetries),
+				WithRetryBackoff(time.Millisecond),
+			)
+
+			errs := sender.Send(context.Background(), &payload{Type: "node-synced"})
+			if (len(errs) > 0) != test.wantErr {
+				t.Fatalf("got errors %v, want error: %t", errs, test.wantErr)
+			}
+			if stub.attempts != test.wantAttempts {
+				t.Fatalf("got %d attempts, want %d", stub.attempts, test.wantAttempts)
+			}
+			if test.wantErr {
+				return
+			}
+
+			var received payload
+			if err := json.Unmarshal(stub.bodies[0], &received); err != nil {
+				t.Fatal(err)
+			}
+			if received.Type != "node-synced" {
+				t.Fatalf("got payload type %q", received.Type)
+			}
+
+			wantSignature := ""
+			if test.secret != "" {
+				wantSignature = Signature([]byte(test.secret), stub.bodies[0])
+			}
+			if stub.signatures[0] != wantSignature {
+				t.Fatalf("got signature %q, want %q", stub.signatures[0], wantSignature)
+			}
+		})
+	}
+}
+
+func TestSenderSendReportsEveryFailedURL(t *testing.T) {
+	stub := &webhookStub{}
+	server := httptest.NewServer(stub)
+	defer server.Close()
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	sender := NewSender([]string{failing.URL, server.URL, failing.URL})
+
+	if errs := sender.Send(context.Background(), map[string]string{"type": "node-unhealthy"}); len(errs) != 2 {
+		t.Fatalf("got %d errors, want 2", len(errs))
+	}
+	if len(stub.bodies) != 1 {
+		t.Fatalf("got %d deliveries to the working endpoint, want 1", len(stub.bodies))
+	}
+}
+
+func TestSenderSendStopsRetryingOnCancel(t *testing.T) {
+	stub := &webhookStub{failures: 100}
+	server := httptest.NewServer(stub)
+	defer server.Close()
+
+	sender := NewSender([]string{server.URL}, WithMaxRetries(10), WithRetryBackoff(time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if errs := sender.Send(ctx, struct{}{}); len(errs) != 1 {
+		t.Fatalf("got %d errors, want 1", len(errs))
+	}
+	if stub.attempts != 1 {
+		t.Fatalf("got %d attempts, want 1", stub.attempts)
+	}
+}
+
+func TestSignature(t *testing.T) {
+	// echo -n '{"type":"node-synced"}' | openssl dgst -sha256 -hmac secret
+	const want = "sha256=975f5d47b712910e21949b5ed22200ed46a2df31848667df6986d394c0333298"
+
+	if got := Signature([]byte("secret"), []byte(`{"type":"node-synced"}`)); got != want {
+		t.Fatalf("got signature %q, want %q", got, want)
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -8,6 +9,7 @@ import (
 	"github.com/iotaledger/hive.go/app"
 	"github.com/iotaledger/hive.go/apputils/config"
 	dashboardApp "github.com/iotaledger/inx-dashboard/components/app"
+	"github.com/iotaledger/inx-dashboard/pkg/dashboard"
 )
 
 func createMarkdownFile(app *app.App, markdownHeaderPath string, markdownFilePath string, ignoreFlags map[string]struct{}, replaceTopicNames map[string]string) {
@@ -55,6 +57,26 @@ func createDefaultConfigFile(app *app.App, configFilePath string, ignoreFlags ma
 	println(fmt.Sprintf("Default configuration file for %s stored: %s", app.Info().Name, configFilePath))
 }
 
+func createOpenAPIFile(app *app.App, openAPIFilePath string) {
+	println(fmt.Sprintf("Create OpenAPI specification for %s...", app.Info().Name))
+
+	// the specification describes the default routes
+	routeRegistry, err := dashboard.NewRouteRegistry(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	spec, err := json.MarshalIndent(dashboard.NewOpenAPIDocument(app.Info().Version, routeRegistry.Routes()), "", "  ")
+	if err != nil {
+		panic(err)
+	}
+
+	if err := os.WriteFile(openAPIFilePath, append(spec, '\n'), os.ModePerm); err != nil {
+		panic(err)
+	}
+	println(fmt.Sprintf("OpenAPI specification for %s stored: %s", app.Info().Name, openAPIFilePath))
+}
+
 func main() {
 
 	// MUST BE LOWER CASE
@@ -79,4 +101,9 @@ func main() {
 		"../../config_defaults.json",
 		ignoreFlags,
 	)
+
+	createOpenAPIFile(
+		application,
+		"../../openapi.json",
+	)
 }